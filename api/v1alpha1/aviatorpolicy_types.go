@@ -17,17 +17,32 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // SelectionMode defines how pods are selected for routing.
-// +kubebuilder:validation:Enum=topN;percentage;threshold
+// +kubebuilder:validation:Enum=topN;percentage;threshold;outlier;score
 type SelectionMode string
 
 const (
 	SelectionModeTopN       SelectionMode = "topN"
 	SelectionModePercentage SelectionMode = "percentage"
 	SelectionModeThreshold  SelectionMode = "threshold"
+	SelectionModeOutlier    SelectionMode = "outlier"
+	SelectionModeScore      SelectionMode = "score"
+)
+
+// OutlierMethod defines how a pod's distance from the fleet median is measured.
+// +kubebuilder:validation:Enum=median;mad
+type OutlierMethod string
+
+const (
+	// OutlierMethodMedian excludes pods whose P99 exceeds factor × median.
+	OutlierMethodMedian OutlierMethod = "median"
+	// OutlierMethodMAD excludes pods whose P99 exceeds median + factor × MAD
+	// (median absolute deviation).
+	OutlierMethodMAD OutlierMethod = "mad"
 )
 
 // LatencySourceType defines where latency data comes from.
@@ -39,20 +54,133 @@ const (
 	LatencySourceProbe LatencySourceType = "probe"
 )
 
-// TargetRef references the Kubernetes Service to manage.
+// WarmUpMode defines how pods without latency data are treated.
+// +kubebuilder:validation:Enum=exclude;include;median;slowStart
+type WarmUpMode string
+
+const (
+	// WarmUpModeExclude leaves unmeasured pods out of rotation.
+	WarmUpModeExclude WarmUpMode = "exclude"
+	// WarmUpModeInclude keeps unmeasured pods in rotation regardless of selection.
+	WarmUpModeInclude WarmUpMode = "include"
+	// WarmUpModeMedian ranks unmeasured pods at the fleet median latency.
+	WarmUpModeMedian WarmUpMode = "median"
+	// WarmUpModeSlowStart ranks unmeasured pods at the fleet's slowest latency
+	// and ramps them towards the median over the warm-up period.
+	WarmUpModeSlowStart WarmUpMode = "slowStart"
+)
+
+// ExplorationMode defines how pods excluded by selection are explored.
+// +kubebuilder:validation:Enum=epsilonGreedy;periodic;probe
+type ExplorationMode string
+
+const (
+	// ExplorationModeEpsilonGreedy reinstates each excluded pod with a fixed
+	// probability on every evaluation.
+	ExplorationModeEpsilonGreedy ExplorationMode = "epsilonGreedy"
+	// ExplorationModePeriodic reinstates each excluded pod once per interval.
+	ExplorationModePeriodic ExplorationMode = "periodic"
+	// ExplorationModeProbe sends probe traffic to each excluded pod once per
+	// interval without returning it to rotation.
+	ExplorationModeProbe ExplorationMode = "probe"
+)
+
+// SmoothingMethod defines how per-pod latency history is combined.
+// +kubebuilder:validation:Enum=ewma;windowQuantile
+type SmoothingMethod string
+
+const (
+	// SmoothingMethodEWMA ranks on an exponentially weighted moving average.
+	SmoothingMethodEWMA SmoothingMethod = "ewma"
+	// SmoothingMethodWindowQuantile ranks on a quantile of recent snapshots.
+	SmoothingMethodWindowQuantile SmoothingMethod = "windowQuantile"
+)
+
+// PolicyMode defines whether a policy changes routing.
+// +kubebuilder:validation:Enum=enforce;observe
+type PolicyMode string
+
+const (
+	// PolicyModeEnforce writes the selection to EndpointSlices.
+	PolicyModeEnforce PolicyMode = "enforce"
+	// PolicyModeObserve runs the full pipeline but only reports what it would
+	// do, in status and Events. Routing is left untouched.
+	PolicyModeObserve PolicyMode = "observe"
+)
+
+// RoutingMode defines how Aviator's EndpointSlices take effect.
+// +kubebuilder:validation:Enum=parallel;derivedService;takeOver
+type RoutingMode string
+
+const (
+	// RoutingModeParallel adds Aviator's slices next to the ones
+	// kube-controller-manager maintains for the target Service. Every pod
+	// still receives some traffic.
+	RoutingModeParallel RoutingMode = "parallel"
+	// RoutingModeDerivedService manages a selectorless Service whose only
+	// slices are Aviator's. Clients opt in by using that Service.
+	RoutingModeDerivedService RoutingMode = "derivedService"
+	// RoutingModeTakeOver removes the target Service's selector so that
+	// Aviator's slices are its only ones. The selector is restored when the
+	// policy is deleted.
+	RoutingModeTakeOver RoutingMode = "takeOver"
+)
+
+// Annotations that let operators override the controller's decisions.
+const (
+	// PinAnnotation set to "true" on a pod keeps the pod selected.
+	PinAnnotation = "aviator.io/pin"
+	// ExcludeAnnotation set to "true" on a pod keeps the pod out of the
+	// selection. It takes precedence over PinAnnotation.
+	ExcludeAnnotation = "aviator.io/exclude"
+	// ForceEjectAnnotation on a policy lists, comma-separated, the names of
+	// pods the circuit breaker keeps ejected. Once a pod is no longer
+	// listed, it recovers as usual.
+	ForceEjectAnnotation = "aviator.io/force-eject"
+	// ResetCircuitBreakerAnnotation on a policy resets the circuit breaker,
+	// re-admitting every pod, each time its value changes.
+	ResetCircuitBreakerAnnotation = "aviator.io/reset-circuit-breaker"
+)
+
+// Manual override types reported in status.
+const (
+	ManualOverridePin        = "Pin"
+	ManualOverrideExclude    = "Exclude"
+	ManualOverrideForceEject = "ForceEject"
+)
+
+// Kinds of resource a policy can target.
+const (
+	TargetKindService     = "Service"
+	TargetKindDeployment  = "Deployment"
+	TargetKindStatefulSet = "StatefulSet"
+	TargetKindRollout     = "Rollout"
+	TargetKindPod         = "Pod"
+)
+
+// TargetRef references the Service or workload whose traffic to manage.
+// For any kind other than Service, the controller resolves the pods itself
+// and owns the selectorless Service that fronts them.
 type TargetRef struct {
-	// API version of the target resource.
+	// API version of the target resource. The kind alone determines how
+	// the target is resolved.
 	// +kubebuilder:default="v1"
 	APIVersion string `json:"apiVersion,omitempty"`
 
-	// Kind of the target resource. Must be "Service".
+	// Kind of the target resource: a Service, a Deployment or StatefulSet,
+	// an Argo Rollout, or Pod to match pods by selector.
 	// +kubebuilder:default="Service"
-	// +kubebuilder:validation:Enum=Service
+	// +kubebuilder:validation:Enum=Service;Deployment;StatefulSet;Rollout;Pod
 	Kind string `json:"kind,omitempty"`
 
-	// Name of the target Service.
+	// Name of the target resource. For kind Pod, the name the fronting
+	// Service is derived from.
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
+
+	// Label selector for the target pods. Required when kind is Pod.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
 }
 
 // SelectionPolicy configures how pods are selected for traffic routing.
@@ -72,9 +200,77 @@ type SelectionPolicy struct {
 	// +kubebuilder:default=50
 	// +optional
 	Percentage *int32 `json:"percentage,omitempty"`
+
+	// Outlier configures outlier detection. Used when mode is "outlier".
+	// +optional
+	Outlier *OutlierDetection `json:"outlier,omitempty"`
+
+	// Weights of the signals combined into the composite score. Used when mode
+	// is "score"; pods are ranked by score and the top N (if topN is set) or
+	// top percentage are selected.
+	// +optional
+	Weights *ScoreWeights `json:"weights,omitempty"`
+}
+
+// ScoreWeights declares the relative weight of each scoring signal. Every
+// signal is normalised across the fleet before weighting; a zero weight
+// disables the signal. If all weights are zero, pods are scored on P99 alone.
+type ScoreWeights struct {
+	// Weight of the observed P50 latency.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	P50 int32 `json:"p50,omitempty"`
+
+	// Weight of the observed P99 latency.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	P99 int32 `json:"p99,omitempty"`
+
+	// Weight of the observed error rate.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ErrorRate int32 `json:"errorRate,omitempty"`
+
+	// Weight of CPU usage relative to the pod's request, from metrics.k8s.io.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CPU int32 `json:"cpu,omitempty"`
+
+	// Weight of memory usage relative to the pod's request, from metrics.k8s.io.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Memory int32 `json:"memory,omitempty"`
+
+	// Weight of the pod's total container restart count.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Restarts int32 `json:"restarts,omitempty"`
 }
 
-// CircuitBreakerSpec configures automatic pod ejection on sustained high latency.
+// OutlierDetection excludes only pods that are slow relative to the fleet
+// median, so a uniformly healthy fleet keeps every pod in rotation.
+type OutlierDetection struct {
+	// Method used to measure distance from the fleet median.
+	// +kubebuilder:default="median"
+	// +optional
+	Method OutlierMethod `json:"method,omitempty"`
+
+	// Factor is k: the multiple of the median (median method) or the number of
+	// MADs above the median (mad method) beyond which a pod is an outlier.
+	// +kubebuilder:default="3"
+	// +optional
+	Factor *resource.Quantity `json:"factor,omitempty"`
+
+	// Minimum number of latency samples a pod needs before it can be judged.
+	// Pods with fewer samples are never excluded as outliers.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=3
+	// +optional
+	MinSamples *int32 `json:"minSamples,omitempty"`
+}
+
+// CircuitBreakerSpec configures automatic pod ejection on sustained high
+// latency or errors, or on unreachable pods.
 type CircuitBreakerSpec struct {
 	// Enable circuit breaker functionality.
 	// +kubebuilder:default=false
@@ -84,11 +280,29 @@ type CircuitBreakerSpec struct {
 	// +kubebuilder:default="500ms"
 	P99Threshold metav1.Duration `json:"p99Threshold,omitempty"`
 
+	// Error rate, in percent of samples, at or above which a measurement is
+	// a violation even if it is fast. Zero disables the check.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	ErrorRateThreshold int32 `json:"errorRateThreshold,omitempty"`
+
 	// Number of consecutive violations before ejecting a pod.
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:default=3
 	ConsecutiveViolations int32 `json:"consecutiveViolations,omitempty"`
 
+	// Number of failed probes in a row, across evaluations, that ejects a
+	// pod at once. Zero disables the check. Only the probe source reports
+	// failures.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Eject a pod at once when none of its probes can connect.
+	// +optional
+	EjectUnreachable bool `json:"ejectUnreachable,omitempty"`
+
 	// How often to probe ejected pods for recovery.
 	// +kubebuilder:default="30s"
 	RecoveryInterval metav1.Duration `json:"recoveryInterval,omitempty"`
@@ -110,6 +324,209 @@ type DampeningSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:default=3
 	ConsecutiveIntervals int32 `json:"consecutiveIntervals,omitempty"`
+
+	// Per-pod hysteresis with separate exit and entry thresholds.
+	// +optional
+	Hysteresis *HysteresisSpec `json:"hysteresis,omitempty"`
+}
+
+// HysteresisSpec gates each pod individually before selection, so a single
+// pod oscillating around a threshold does not churn the endpoint set.
+type HysteresisSpec struct {
+	// P99 above which an active pod counts towards removal.
+	ExitThreshold metav1.Duration `json:"exitThreshold"`
+
+	// P99 below which a removed pod counts towards re-admission. Should be
+	// lower than exitThreshold.
+	EntryThreshold metav1.Duration `json:"entryThreshold"`
+
+	// Consecutive intervals above exitThreshold before a pod is removed.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	ExitIntervals int32 `json:"exitIntervals,omitempty"`
+
+	// Consecutive intervals below entryThreshold before a pod is re-added.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3
+	// +optional
+	EntryIntervals int32 `json:"entryIntervals,omitempty"`
+}
+
+// WarmUpSpec configures how pods without latency data are treated. With a
+// passive latency source a pod only produces samples once it receives
+// traffic, so excluding unmeasured pods can keep new pods out indefinitely.
+type WarmUpSpec struct {
+	// Mode for pods still within their warm-up period.
+	// +kubebuilder:default="include"
+	// +optional
+	Mode WarmUpMode `json:"mode,omitempty"`
+
+	// Warm-up period, measured from when the pod became ready. A pod that is
+	// still unmeasured afterwards is excluded until it produces data.
+	// +kubebuilder:default="60s"
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// DrainSpec keeps pods that leave the selected set in the EndpointSlice for
+// a while, not serving and terminating, so that proxies stop sending them new
+// connections without cutting existing ones.
+type DrainSpec struct {
+	// How long a removed pod stays in the slice.
+	// +kubebuilder:default="30s"
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// ExplorationSpec keeps measurements of excluded pods fresh. With a passive
+// latency source, a pod removed from rotation stops producing samples and
+// could otherwise never earn its way back.
+type ExplorationSpec struct {
+	// Exploration strategy.
+	// +kubebuilder:default="periodic"
+	// +optional
+	Mode ExplorationMode `json:"mode,omitempty"`
+
+	// Percentage chance per evaluation that an excluded pod is reinstated
+	// (epsilonGreedy mode).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	EpsilonPercent int32 `json:"epsilonPercent,omitempty"`
+
+	// Interval between explorations of the same pod (periodic and probe modes).
+	// +kubebuilder:default="60s"
+	// +optional
+	Interval metav1.Duration `json:"interval,omitempty"`
+
+	// How long a reinstated pod stays in rotation (epsilonGreedy and periodic modes).
+	// +kubebuilder:default="10s"
+	// +optional
+	Duration metav1.Duration `json:"duration,omitempty"`
+}
+
+// RoutingSpec controls how Aviator's EndpointSlices take effect.
+type RoutingSpec struct {
+	// Routing mode.
+	// +kubebuilder:default="parallel"
+	// +optional
+	Mode RoutingMode `json:"mode,omitempty"`
+
+	// Name of the derived Service (derivedService mode). Defaults to
+	// "<service>-fast".
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+}
+
+// SmoothingSpec ranks pods on per-pod latency history instead of the latest
+// snapshot alone, so a single noisy measurement round cannot reshuffle the
+// ranking.
+type SmoothingSpec struct {
+	// Smoothing method.
+	// +kubebuilder:default="ewma"
+	// +optional
+	Method SmoothingMethod `json:"method,omitempty"`
+
+	// Half-life of the moving average (ewma method).
+	// +kubebuilder:default="30s"
+	// +optional
+	HalfLife metav1.Duration `json:"halfLife,omitempty"`
+
+	// Length of history considered (windowQuantile method).
+	// +kubebuilder:default="60s"
+	// +optional
+	Window metav1.Duration `json:"window,omitempty"`
+
+	// Quantile of the snapshots in the window (windowQuantile method).
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=50
+	// +optional
+	Quantile int32 `json:"quantile,omitempty"`
+
+	// Pods with fewer accumulated samples are pulled towards the fleet
+	// median in proportion to how far short they fall. Zero disables this.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=10
+	// +optional
+	MinSamples int32 `json:"minSamples,omitempty"`
+}
+
+// GuardrailsSpec bounds how far Aviator may shrink the set of pods receiving
+// traffic, regardless of what selection or the circuit breaker decide.
+type GuardrailsSpec struct {
+	// Minimum number of pods kept in rotation.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinActivePods int32 `json:"minActivePods,omitempty"`
+
+	// Minimum percentage of measured pods kept in rotation (rounded up).
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinActivePercent int32 `json:"minActivePercent,omitempty"`
+
+	// Maximum percentage of measured pods the circuit breaker may eject at
+	// once. When exceeded, the least severe ejections are ignored. Unset means
+	// no cap.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
+}
+
+// TopologySpec keeps selection zone-aware so no zone is left without local
+// endpoints. Zones are read from the topology.kubernetes.io/zone Node label.
+type TopologySpec struct {
+	// Minimum number of pods selected in every zone that has eligible pods.
+	// A zone with fewer eligible pods contributes all of them.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=1
+	MinPodsPerZone int32 `json:"minPodsPerZone,omitempty"`
+}
+
+// ScheduleWindow is a recurring window. It opens each time its cron
+// expression matches and stays open for its duration.
+type ScheduleWindow struct {
+	// Name identifies the window in status and Events.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Five-field cron expression (minute hour day-of-month month
+	// day-of-week) for when the window opens, e.g. "0 9 * * mon-fri".
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron"`
+
+	// How long the window stays open each time it opens.
+	Duration metav1.Duration `json:"duration"`
+
+	// IANA time zone the cron expression is evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// ScheduledOverride replaces parts of the spec while its window is open.
+// Each set field replaces the corresponding spec field as a whole.
+type ScheduledOverride struct {
+	ScheduleWindow `json:",inline"`
+
+	// Replaces latencyThreshold.
+	// +optional
+	LatencyThreshold *metav1.Duration `json:"latencyThreshold,omitempty"`
+
+	// Replaces selection.
+	// +optional
+	Selection *SelectionPolicy `json:"selection,omitempty"`
+
+	// Replaces circuitBreaker.
+	// +optional
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+
+	// Replaces dampening.
+	// +optional
+	Dampening *DampeningSpec `json:"dampening,omitempty"`
 }
 
 // AviatorPolicySpec defines the desired state of AviatorPolicy.
@@ -117,6 +534,12 @@ type AviatorPolicySpec struct {
 	// Reference to the target Kubernetes Service.
 	TargetRef TargetRef `json:"targetRef"`
 
+	// Whether the policy changes routing (enforce) or only reports what it
+	// would do (observe).
+	// +kubebuilder:default="enforce"
+	// +optional
+	Mode PolicyMode `json:"mode,omitempty"`
+
 	// Maximum acceptable latency for pod selection (threshold mode).
 	// +kubebuilder:default="100ms"
 	LatencyThreshold metav1.Duration `json:"latencyThreshold,omitempty"`
@@ -137,11 +560,59 @@ type AviatorPolicySpec struct {
 	// +optional
 	Dampening *DampeningSpec `json:"dampening,omitempty"`
 
+	// Topology enforces per-zone selection minimums.
+	// +optional
+	Topology *TopologySpec `json:"topology,omitempty"`
+
+	// Guardrails protect availability against aggressive selection or ejection.
+	// +optional
+	Guardrails *GuardrailsSpec `json:"guardrails,omitempty"`
+
+	// Drain keeps pods that leave the selected set in the slice for a drain
+	// period instead of dropping them at once.
+	// +optional
+	Drain *DrainSpec `json:"drain,omitempty"`
+
+	// WarmUp configures handling of pods without latency data. When unset,
+	// unmeasured pods are excluded.
+	// +optional
+	WarmUp *WarmUpSpec `json:"warmUp,omitempty"`
+
+	// Smoothing ranks pods on latency history rather than the latest snapshot.
+	// +optional
+	Smoothing *SmoothingSpec `json:"smoothing,omitempty"`
+
+	// Exploration periodically returns excluded pods to rotation, or probes
+	// them, so their latency stays fresh.
+	// +optional
+	Exploration *ExplorationSpec `json:"exploration,omitempty"`
+
+	// Routing controls how Aviator's EndpointSlices take effect. Defaults to
+	// parallel mode.
+	// +optional
+	Routing *RoutingSpec `json:"routing,omitempty"`
+
+	// Schedules override settings while their window is open. When several
+	// are open, later schedules take precedence.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Schedules []ScheduledOverride `json:"schedules,omitempty"`
+
+	// Freezes are windows during which EndpointSlices are left unchanged.
+	// Evaluation, status and reports carry on.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Freezes []ScheduleWindow `json:"freezes,omitempty"`
+
 	// Source of latency data.
 	// +kubebuilder:default="ebpf"
 	LatencySource LatencySourceType `json:"latencySource,omitempty"`
 
-	// Port to probe when using "probe" latency source.
+	// Port to probe when using "probe" latency source. If unset, each pod is
+	// probed on the Service's first TCP target port, with named ports
+	// resolved against the pod's containers.
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
 	// +optional
@@ -154,16 +625,93 @@ type PodLatencyInfo struct {
 	Name string `json:"name"`
 	// Pod IP address.
 	PodIP string `json:"podIP,omitempty"`
+	// Topology zone of the pod's node.
+	Zone string `json:"zone,omitempty"`
 	// Observed P50 latency.
 	P50 metav1.Duration `json:"p50,omitempty"`
 	// Observed P99 latency.
 	P99 metav1.Duration `json:"p99,omitempty"`
 	// Whether the pod is circuit-broken.
 	CircuitBroken bool `json:"circuitBroken,omitempty"`
+	// Condition behind the circuit breaker's latest transition for the pod:
+	// Latency, ErrorRate, ConsecutiveFailures, Unreachable, Forced,
+	// RecoveryInterval or Recovered.
+	// +optional
+	CircuitBreakerCause string `json:"circuitBreakerCause,omitempty"`
+	// Composite score on a 0–1000 scale, lower is better (score mode only).
+	// +optional
+	Score *int32 `json:"score,omitempty"`
+	// Per-signal contributions to Score, on the same scale (score mode only).
+	// +optional
+	ScoreBreakdown map[string]int32 `json:"scoreBreakdown,omitempty"`
+}
+
+// DrainingPodInfo reports a pod draining after it left the selected set.
+type DrainingPodInfo struct {
+	// Pod name.
+	Name string `json:"name"`
+	// Pod IP address.
+	PodIP string `json:"podIP,omitempty"`
+	// When the pod left the selected set.
+	Since metav1.Time `json:"since"`
+	// When the pod is dropped from the EndpointSlice.
+	Until metav1.Time `json:"until"`
+}
+
+// Unmeasured pod states reported in status.
+const (
+	UnmeasuredPodWarmingUp = "WarmingUp"
+	UnmeasuredPodExcluded  = "Excluded"
+)
+
+// ManualOverride reports an operator override applied to a pod.
+type ManualOverride struct {
+	// Pod name.
+	Pod string `json:"pod"`
+	// Override type: Pin, Exclude or ForceEject.
+	Type string `json:"type"`
+}
+
+// UnmeasuredPodInfo reports a pod with no latency data.
+type UnmeasuredPodInfo struct {
+	// Pod name.
+	Name string `json:"name"`
+	// Pod IP address.
+	PodIP string `json:"podIP,omitempty"`
+	// WarmingUp while the pod is handled by the warm-up mode, Excluded otherwise.
+	State string `json:"state"`
+	// Time the pod became ready.
+	ReadySince metav1.Time `json:"readySince,omitempty"`
+}
+
+// ObservedRouting reports what an observe-mode policy would do.
+type ObservedRouting struct {
+	// Pods the policy would route to.
+	SelectedPods []string `json:"selectedPods,omitempty"`
+	// Pods that would be added to the Service's current endpoints.
+	Added []string `json:"added,omitempty"`
+	// Pods that would be removed from the Service's current endpoints.
+	Removed []string `json:"removed,omitempty"`
+}
+
+// EndpointSliceWriteStats counts EndpointSlice writes since the policy was
+// created.
+type EndpointSliceWriteStats struct {
+	// Slices written with server-side apply.
+	Applied int64 `json:"applied"`
+	// Writes skipped because the slice content was unchanged.
+	Skipped int64 `json:"skipped"`
+	// Writes that hit a field ownership conflict and were retried with forced
+	// ownership.
+	Conflicted int64 `json:"conflicted"`
 }
 
 // AviatorPolicyStatus defines the observed state of AviatorPolicy.
 type AviatorPolicyStatus struct {
+	// Generation of the spec the status was last computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// Timestamp of the last latency evaluation.
 	LastEvaluationTime metav1.Time `json:"lastEvaluationTime,omitempty"`
 
@@ -185,6 +733,54 @@ type AviatorPolicyStatus struct {
 	// Per-pod latency details (top 10 pods).
 	PodLatencies []PodLatencyInfo `json:"podLatencies,omitempty"`
 
+	// Pods without latency data and their warm-up state (first 10 pods).
+	UnmeasuredPods []UnmeasuredPodInfo `json:"unmeasuredPods,omitempty"`
+
+	// Excluded pods currently being explored.
+	ExploringPods []string `json:"exploringPods,omitempty"`
+
+	// Pods draining after they left the selected set (first 10 pods).
+	// +optional
+	DrainingPods []DrainingPodInfo `json:"drainingPods,omitempty"`
+
+	// What the policy would do, in observe mode.
+	// +optional
+	Observed *ObservedRouting `json:"observed,omitempty"`
+
+	// Service that Aviator's EndpointSlices are attached to.
+	// +optional
+	RoutedService string `json:"routedService,omitempty"`
+
+	// Outcome of EndpointSlice writes.
+	// +optional
+	EndpointSliceWrites EndpointSliceWriteStats `json:"endpointSliceWrites,omitempty"`
+
+	// Names of the schedules whose overrides are in effect.
+	// +optional
+	ActiveSchedules []string `json:"activeSchedules,omitempty"`
+
+	// When the current freeze ends, while one is in effect.
+	// +optional
+	FrozenUntil *metav1.Time `json:"frozenUntil,omitempty"`
+
+	// Manual overrides applied in the last evaluation (first 10).
+	// +optional
+	ManualOverrides []ManualOverride `json:"manualOverrides,omitempty"`
+
+	// Value of the aviator.io/reset-circuit-breaker annotation last acted on.
+	// +optional
+	CircuitBreakerReset string `json:"circuitBreakerReset,omitempty"`
+
+	// ClusterAviatorPolicies that select the policy's namespace, by name.
+	// +optional
+	ClusterPolicies []string `json:"clusterPolicies,omitempty"`
+
+	// The spec the last evaluation used: the policy's own, with cluster
+	// defaults filled in, open schedules applied and cluster limits
+	// enforced.
+	// +optional
+	EffectiveSpec *AviatorPolicySpec `json:"effectiveSpec,omitempty"`
+
 	// Standard conditions for the policy.
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -192,11 +788,13 @@ type AviatorPolicyStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:shortName=avp
 // +kubebuilder:printcolumn:name="Active",type=integer,JSONPath=`.status.activePods`
 // +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.status.totalPods`
 // +kubebuilder:printcolumn:name="P99ms",type=integer,JSONPath=`.status.p99LatencyMs`
 // +kubebuilder:printcolumn:name="Source",type=string,JSONPath=`.spec.latencySource`
+// +kubebuilder:printcolumn:name="Mode",type=string,JSONPath=`.spec.mode`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // AviatorPolicy is the Schema for the aviatorpolicies API.
@@ -0,0 +1,141 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Reasons a pod was left out of the selection, reported in PodReport.
+const (
+	// ExclusionCircuitBroken means the circuit breaker ejected the pod.
+	ExclusionCircuitBroken = "CircuitBroken"
+	// ExclusionHysteresis means per-pod hysteresis is holding the pod out.
+	ExclusionHysteresis = "Hysteresis"
+	// ExclusionNotSelected means the selection mode ranked the pod out.
+	ExclusionNotSelected = "NotSelected"
+	// ExclusionDampened means the pod would be selected, but dampening is
+	// holding back the change.
+	ExclusionDampened = "Dampened"
+	// ExclusionUnmeasured means the pod has no latency data and is not
+	// warming up.
+	ExclusionUnmeasured = "Unmeasured"
+	// ExclusionManual means the pod carries the aviator.io/exclude
+	// annotation.
+	ExclusionManual = "ManuallyExcluded"
+)
+
+// ReportSummary sums up the evaluation a report describes.
+type ReportSummary struct {
+	// Time of the evaluation.
+	EvaluationTime metav1.Time `json:"evaluationTime"`
+	// Generation of the policy spec that was evaluated.
+	// +optional
+	PolicyGeneration int64 `json:"policyGeneration,omitempty"`
+	// Number of pods considered.
+	Total int32 `json:"total"`
+	// Number of pods selected.
+	Selected int32 `json:"selected"`
+	// Number of pods ejected by the circuit breaker.
+	// +optional
+	Ejected int32 `json:"ejected,omitempty"`
+	// Number of pods left out of the report because it reached its size
+	// limit. The worst ranked pods are left out first.
+	// +optional
+	Truncated int32 `json:"truncated,omitempty"`
+}
+
+// PodReport describes how one pod fared in an evaluation.
+type PodReport struct {
+	// Pod name.
+	Name string `json:"name"`
+	// Pod IP address.
+	PodIP string `json:"podIP,omitempty"`
+	// Topology zone of the pod's node.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+	// Position in the ranking, starting at 1. Unset for pods that were not
+	// ranked.
+	// +optional
+	Rank int32 `json:"rank,omitempty"`
+	// Observed, smoothed or estimated P50 latency.
+	// +optional
+	P50 metav1.Duration `json:"p50,omitempty"`
+	// Observed, smoothed or estimated P99 latency.
+	// +optional
+	P99 metav1.Duration `json:"p99,omitempty"`
+	// Whether the latencies are a warm-up estimate rather than measured.
+	// +optional
+	Estimated bool `json:"estimated,omitempty"`
+	// Composite score on a 0–1000 scale, lower is better (score mode only).
+	// +optional
+	Score *int32 `json:"score,omitempty"`
+	// Whether the pod is selected.
+	Selected bool `json:"selected"`
+	// Manual override applied to the pod: Pin, Exclude or ForceEject.
+	// +optional
+	Override string `json:"override,omitempty"`
+	// Circuit breaker state: closed, open or half-open. Unset when the
+	// circuit breaker is disabled.
+	// +optional
+	CircuitBreaker string `json:"circuitBreaker,omitempty"`
+	// Condition behind the circuit breaker's latest transition for the pod,
+	// such as ErrorRate or Unreachable.
+	// +optional
+	CircuitBreakerCause string `json:"circuitBreakerCause,omitempty"`
+	// Why the pod is not selected.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Human-readable detail of the reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=avpr
+// +kubebuilder:printcolumn:name="Total",type=integer,JSONPath=`.summary.total`
+// +kubebuilder:printcolumn:name="Selected",type=integer,JSONPath=`.summary.selected`
+// +kubebuilder:printcolumn:name="Ejected",type=integer,JSONPath=`.summary.ejected`
+// +kubebuilder:printcolumn:name="Evaluated",type=date,JSONPath=`.summary.evaluationTime`
+
+// AviatorPolicyReport holds the full per-pod ranking of an AviatorPolicy's
+// latest evaluation. It has the policy's name and is owned by it.
+type AviatorPolicyReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Summary of the evaluation.
+	Summary ReportSummary `json:"summary"`
+
+	// Every pod considered, best ranked first. Pods that were not ranked
+	// come last.
+	// +optional
+	Pods []PodReport `json:"pods,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AviatorPolicyReportList contains a list of AviatorPolicyReport.
+type AviatorPolicyReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AviatorPolicyReport `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AviatorPolicyReport{}, &AviatorPolicyReportList{})
+}
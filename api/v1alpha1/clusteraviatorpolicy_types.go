@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PolicyDefaults fill in settings that policies leave unset. Each set field
+// is used as a whole when the policy does not set the corresponding field.
+type PolicyDefaults struct {
+	// Default circuit breaker configuration.
+	// +optional
+	CircuitBreaker *CircuitBreakerSpec `json:"circuitBreaker,omitempty"`
+
+	// Default dampening configuration.
+	// +optional
+	Dampening *DampeningSpec `json:"dampening,omitempty"`
+
+	// Default per-zone selection minimums.
+	// +optional
+	Topology *TopologySpec `json:"topology,omitempty"`
+
+	// Default availability guardrails.
+	// +optional
+	Guardrails *GuardrailsSpec `json:"guardrails,omitempty"`
+
+	// Default connection draining.
+	// +optional
+	Drain *DrainSpec `json:"drain,omitempty"`
+
+	// Default handling of pods without latency data.
+	// +optional
+	WarmUp *WarmUpSpec `json:"warmUp,omitempty"`
+
+	// Default latency smoothing.
+	// +optional
+	Smoothing *SmoothingSpec `json:"smoothing,omitempty"`
+
+	// Default exploration of excluded pods.
+	// +optional
+	Exploration *ExplorationSpec `json:"exploration,omitempty"`
+}
+
+// PolicyLimits bound what policies may do. Settings beyond a limit are
+// clamped to it; the policy is not rejected.
+type PolicyLimits struct {
+	// Shortest evaluation interval policies may use.
+	// +optional
+	MinEvaluationInterval *metav1.Duration `json:"minEvaluationInterval,omitempty"`
+
+	// Lowest guardrails.minActivePods policies may use.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinActivePods *int32 `json:"minActivePods,omitempty"`
+
+	// Lowest guardrails.minActivePercent policies may use.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MinActivePercent *int32 `json:"minActivePercent,omitempty"`
+
+	// Highest guardrails.maxEjectionPercent policies may use. Policies
+	// without a cap get this one.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	MaxEjectionPercent *int32 `json:"maxEjectionPercent,omitempty"`
+
+	// Run every policy in observe mode, leaving routing untouched.
+	// +optional
+	ObserveOnly bool `json:"observeOnly,omitempty"`
+}
+
+// ClusterAviatorPolicySpec defines the desired state of ClusterAviatorPolicy.
+type ClusterAviatorPolicySpec struct {
+	// Namespaces whose AviatorPolicies inherit from this policy. An empty
+	// selector matches every namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Defaults for settings the policies leave unset. When several cluster
+	// policies match, the first by name that sets a field wins.
+	// +optional
+	Defaults *PolicyDefaults `json:"defaults,omitempty"`
+
+	// Limits the policies are held to. When several cluster policies match,
+	// the strictest limit applies.
+	// +optional
+	Limits *PolicyLimits `json:"limits,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=cavp
+
+// ClusterAviatorPolicy supplies defaults and limits to the AviatorPolicies
+// of the namespaces it selects.
+type ClusterAviatorPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterAviatorPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterAviatorPolicyList contains a list of ClusterAviatorPolicy.
+type ClusterAviatorPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterAviatorPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterAviatorPolicy{}, &ClusterAviatorPolicyList{})
+}
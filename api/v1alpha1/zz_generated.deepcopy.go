@@ -87,7 +87,7 @@ func (in *AviatorPolicyList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AviatorPolicySpec) DeepCopyInto(out *AviatorPolicySpec) {
 	*out = *in
-	out.TargetRef = in.TargetRef
+	in.TargetRef.DeepCopyInto(&out.TargetRef)
 	out.LatencyThreshold = in.LatencyThreshold
 	out.EvaluationInterval = in.EvaluationInterval
 	in.Selection.DeepCopyInto(&out.Selection)
@@ -99,8 +99,55 @@ func (in *AviatorPolicySpec) DeepCopyInto(out *AviatorPolicySpec) {
 	if in.Dampening != nil {
 		in, out := &in.Dampening, &out.Dampening
 		*out = new(DampeningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologySpec)
+		**out = **in
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(GuardrailsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainSpec)
+		**out = **in
+	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(WarmUpSpec)
+		**out = **in
+	}
+	if in.Smoothing != nil {
+		in, out := &in.Smoothing, &out.Smoothing
+		*out = new(SmoothingSpec)
 		**out = **in
 	}
+	if in.Exploration != nil {
+		in, out := &in.Exploration, &out.Exploration
+		*out = new(ExplorationSpec)
+		**out = **in
+	}
+	if in.Routing != nil {
+		in, out := &in.Routing, &out.Routing
+		*out = new(RoutingSpec)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScheduledOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Freezes != nil {
+		in, out := &in.Freezes, &out.Freezes
+		*out = make([]ScheduleWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.TargetPort != nil {
 		in, out := &in.TargetPort, &out.TargetPort
 		*out = new(int32)
@@ -130,8 +177,59 @@ func (in *AviatorPolicyStatus) DeepCopyInto(out *AviatorPolicyStatus) {
 	if in.PodLatencies != nil {
 		in, out := &in.PodLatencies, &out.PodLatencies
 		*out = make([]PodLatencyInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UnmeasuredPods != nil {
+		in, out := &in.UnmeasuredPods, &out.UnmeasuredPods
+		*out = make([]UnmeasuredPodInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExploringPods != nil {
+		in, out := &in.ExploringPods, &out.ExploringPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainingPods != nil {
+		in, out := &in.DrainingPods, &out.DrainingPods
+		*out = make([]DrainingPodInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Observed != nil {
+		in, out := &in.Observed, &out.Observed
+		*out = new(ObservedRouting)
+		(*in).DeepCopyInto(*out)
+	}
+	out.EndpointSliceWrites = in.EndpointSliceWrites
+	if in.ActiveSchedules != nil {
+		in, out := &in.ActiveSchedules, &out.ActiveSchedules
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FrozenUntil != nil {
+		in, out := &in.FrozenUntil, &out.FrozenUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.ManualOverrides != nil {
+		in, out := &in.ManualOverrides, &out.ManualOverrides
+		*out = make([]ManualOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterPolicies != nil {
+		in, out := &in.ClusterPolicies, &out.ClusterPolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EffectiveSpec != nil {
+		in, out := &in.EffectiveSpec, &out.EffectiveSpec
+		*out = new(AviatorPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -154,6 +252,11 @@ func (in *AviatorPolicyStatus) DeepCopy() *AviatorPolicyStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetRef) DeepCopyInto(out *TargetRef) {
 	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetRef.
@@ -179,6 +282,16 @@ func (in *SelectionPolicy) DeepCopyInto(out *SelectionPolicy) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Outlier != nil {
+		in, out := &in.Outlier, &out.Outlier
+		*out = new(OutlierDetection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = new(ScoreWeights)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectionPolicy.
@@ -191,6 +304,158 @@ func (in *SelectionPolicy) DeepCopy() *SelectionPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManualOverride) DeepCopyInto(out *ManualOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManualOverride.
+func (in *ManualOverride) DeepCopy() *ManualOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ManualOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObservedRouting) DeepCopyInto(out *ObservedRouting) {
+	*out = *in
+	if in.SelectedPods != nil {
+		in, out := &in.SelectedPods, &out.SelectedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Added != nil {
+		in, out := &in.Added, &out.Added
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Removed != nil {
+		in, out := &in.Removed, &out.Removed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObservedRouting.
+func (in *ObservedRouting) DeepCopy() *ObservedRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(ObservedRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
+	*out = *in
+	if in.Factor != nil {
+		in, out := &in.Factor, &out.Factor
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MinSamples != nil {
+		in, out := &in.MinSamples, &out.MinSamples
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutlierDetection.
+func (in *OutlierDetection) DeepCopy() *OutlierDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(OutlierDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingSpec) DeepCopyInto(out *RoutingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingSpec.
+func (in *RoutingSpec) DeepCopy() *RoutingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledOverride) DeepCopyInto(out *ScheduledOverride) {
+	*out = *in
+	out.ScheduleWindow = in.ScheduleWindow
+	if in.LatencyThreshold != nil {
+		in, out := &in.LatencyThreshold, &out.LatencyThreshold
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Selection != nil {
+		in, out := &in.Selection, &out.Selection
+		*out = new(SelectionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerSpec)
+		**out = **in
+	}
+	if in.Dampening != nil {
+		in, out := &in.Dampening, &out.Dampening
+		*out = new(DampeningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledOverride.
+func (in *ScheduledOverride) DeepCopy() *ScheduledOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScoreWeights) DeepCopyInto(out *ScoreWeights) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScoreWeights.
+func (in *ScoreWeights) DeepCopy() *ScoreWeights {
+	if in == nil {
+		return nil
+	}
+	out := new(ScoreWeights)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreakerSpec) DeepCopyInto(out *CircuitBreakerSpec) {
 	*out = *in
@@ -209,6 +474,11 @@ func (in *CircuitBreakerSpec) DeepCopy() *CircuitBreakerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DampeningSpec) DeepCopyInto(out *DampeningSpec) {
 	*out = *in
+	if in.Hysteresis != nil {
+		in, out := &in.Hysteresis, &out.Hysteresis
+		*out = new(HysteresisSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DampeningSpec.
@@ -221,9 +491,173 @@ func (in *DampeningSpec) DeepCopy() *DampeningSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WarmUpSpec) DeepCopyInto(out *WarmUpSpec) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmUpSpec.
+func (in *WarmUpSpec) DeepCopy() *WarmUpSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WarmUpSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainSpec) DeepCopyInto(out *DrainSpec) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainSpec.
+func (in *DrainSpec) DeepCopy() *DrainSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainingPodInfo) DeepCopyInto(out *DrainingPodInfo) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainingPodInfo.
+func (in *DrainingPodInfo) DeepCopy() *DrainingPodInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainingPodInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSliceWriteStats) DeepCopyInto(out *EndpointSliceWriteStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSliceWriteStats.
+func (in *EndpointSliceWriteStats) DeepCopy() *EndpointSliceWriteStats {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSliceWriteStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExplorationSpec) DeepCopyInto(out *ExplorationSpec) {
+	*out = *in
+	out.Interval = in.Interval
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExplorationSpec.
+func (in *ExplorationSpec) DeepCopy() *ExplorationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExplorationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SmoothingSpec) DeepCopyInto(out *SmoothingSpec) {
+	*out = *in
+	out.HalfLife = in.HalfLife
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SmoothingSpec.
+func (in *SmoothingSpec) DeepCopy() *SmoothingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SmoothingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GuardrailsSpec) DeepCopyInto(out *GuardrailsSpec) {
+	*out = *in
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GuardrailsSpec.
+func (in *GuardrailsSpec) DeepCopy() *GuardrailsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GuardrailsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpec) DeepCopyInto(out *TopologySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpec.
+func (in *TopologySpec) DeepCopy() *TopologySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HysteresisSpec) DeepCopyInto(out *HysteresisSpec) {
+	*out = *in
+	out.ExitThreshold = in.ExitThreshold
+	out.EntryThreshold = in.EntryThreshold
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HysteresisSpec.
+func (in *HysteresisSpec) DeepCopy() *HysteresisSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HysteresisSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodLatencyInfo) DeepCopyInto(out *PodLatencyInfo) {
 	*out = *in
+	out.P50 = in.P50
+	out.P99 = in.P99
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScoreBreakdown != nil {
+		in, out := &in.ScoreBreakdown, &out.ScoreBreakdown
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodLatencyInfo.
@@ -235,3 +669,300 @@ func (in *PodLatencyInfo) DeepCopy() *PodLatencyInfo {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnmeasuredPodInfo) DeepCopyInto(out *UnmeasuredPodInfo) {
+	*out = *in
+	in.ReadySince.DeepCopyInto(&out.ReadySince)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnmeasuredPodInfo.
+func (in *UnmeasuredPodInfo) DeepCopy() *UnmeasuredPodInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(UnmeasuredPodInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AviatorPolicyReport) DeepCopyInto(out *AviatorPolicyReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Summary.DeepCopyInto(&out.Summary)
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PodReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AviatorPolicyReport.
+func (in *AviatorPolicyReport) DeepCopy() *AviatorPolicyReport {
+	if in == nil {
+		return nil
+	}
+	out := new(AviatorPolicyReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AviatorPolicyReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AviatorPolicyReportList) DeepCopyInto(out *AviatorPolicyReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AviatorPolicyReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AviatorPolicyReportList.
+func (in *AviatorPolicyReportList) DeepCopy() *AviatorPolicyReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(AviatorPolicyReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AviatorPolicyReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReportSummary) DeepCopyInto(out *ReportSummary) {
+	*out = *in
+	in.EvaluationTime.DeepCopyInto(&out.EvaluationTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReportSummary.
+func (in *ReportSummary) DeepCopy() *ReportSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(ReportSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodReport) DeepCopyInto(out *PodReport) {
+	*out = *in
+	out.P50 = in.P50
+	out.P99 = in.P99
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodReport.
+func (in *PodReport) DeepCopy() *PodReport {
+	if in == nil {
+		return nil
+	}
+	out := new(PodReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyDefaults) DeepCopyInto(out *PolicyDefaults) {
+	*out = *in
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(CircuitBreakerSpec)
+		**out = **in
+	}
+	if in.Dampening != nil {
+		in, out := &in.Dampening, &out.Dampening
+		*out = new(DampeningSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(TopologySpec)
+		**out = **in
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = new(GuardrailsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(DrainSpec)
+		**out = **in
+	}
+	if in.WarmUp != nil {
+		in, out := &in.WarmUp, &out.WarmUp
+		*out = new(WarmUpSpec)
+		**out = **in
+	}
+	if in.Smoothing != nil {
+		in, out := &in.Smoothing, &out.Smoothing
+		*out = new(SmoothingSpec)
+		**out = **in
+	}
+	if in.Exploration != nil {
+		in, out := &in.Exploration, &out.Exploration
+		*out = new(ExplorationSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyDefaults.
+func (in *PolicyDefaults) DeepCopy() *PolicyDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyLimits) DeepCopyInto(out *PolicyLimits) {
+	*out = *in
+	if in.MinEvaluationInterval != nil {
+		in, out := &in.MinEvaluationInterval, &out.MinEvaluationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MinActivePods != nil {
+		in, out := &in.MinActivePods, &out.MinActivePods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinActivePercent != nil {
+		in, out := &in.MinActivePercent, &out.MinActivePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxEjectionPercent != nil {
+		in, out := &in.MaxEjectionPercent, &out.MaxEjectionPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyLimits.
+func (in *PolicyLimits) DeepCopy() *PolicyLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAviatorPolicySpec) DeepCopyInto(out *ClusterAviatorPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Defaults != nil {
+		in, out := &in.Defaults, &out.Defaults
+		*out = new(PolicyDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(PolicyLimits)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAviatorPolicySpec.
+func (in *ClusterAviatorPolicySpec) DeepCopy() *ClusterAviatorPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAviatorPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAviatorPolicy) DeepCopyInto(out *ClusterAviatorPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAviatorPolicy.
+func (in *ClusterAviatorPolicy) DeepCopy() *ClusterAviatorPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAviatorPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAviatorPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAviatorPolicyList) DeepCopyInto(out *ClusterAviatorPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAviatorPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAviatorPolicyList.
+func (in *ClusterAviatorPolicyList) DeepCopy() *ClusterAviatorPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAviatorPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAviatorPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"aviator/api/v1alpha1"
+)
+
+// conversionAnnotation carries what one version cannot express about an
+// object converted from the other, so that a round trip is lossless. It is
+// removed again when the object is converted back.
+const conversionAnnotation = "aviator.io/conversion-data"
+
+// conversionData is the content of conversionAnnotation.
+type conversionData struct {
+	Spec          *specShape `json:"spec,omitempty"`
+	EffectiveSpec *specShape `json:"effectiveSpec,omitempty"`
+}
+
+// specShape records which fields of a spec were set where the other version
+// cannot tell a set zero value from an unset field.
+type specShape struct {
+	// Schedules describes, by name, v1alpha1 schedules whose latencyThreshold
+	// was zero or whose selection was empty.
+	Schedules map[string]scheduleShape `json:"schedules,omitempty"`
+	// EmptyProbe is set for a v1alpha2 source.probe without a port.
+	EmptyProbe bool `json:"emptyProbe,omitempty"`
+}
+
+// scheduleShape records which of the fields v1alpha2 merges into a
+// schedule's selection were set in v1alpha1.
+type scheduleShape struct {
+	LatencyThreshold bool `json:"latencyThreshold,omitempty"`
+	Selection        bool `json:"selection,omitempty"`
+}
+
+// ConvertTo converts this AviatorPolicy to the Hub version (v1alpha1).
+func (src *AviatorPolicy) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.AviatorPolicy)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 AviatorPolicy but got %T", dstRaw)
+	}
+	in, err := popConversionData(&src.ObjectMeta, &dst.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	// Everything but the regrouped fields has the same schema in both
+	// versions. Fields that only exist in the other version are ignored
+	// when decoding.
+	if err := convertJSON(&src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("converting spec: %w", err)
+	}
+	if err := convertJSON(&src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("converting status: %w", err)
+	}
+
+	var out conversionData
+	out.Spec = specToHub(&src.Spec, &dst.Spec, in.Spec)
+	if src.Status.EffectiveSpec != nil {
+		out.EffectiveSpec = specToHub(src.Status.EffectiveSpec, dst.Status.EffectiveSpec, in.EffectiveSpec)
+	}
+	return pushConversionData(&dst.ObjectMeta, out)
+}
+
+// ConvertFrom converts from the Hub version (v1alpha1) to this version.
+func (dst *AviatorPolicy) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.AviatorPolicy)
+	if !ok {
+		return fmt.Errorf("expected a v1alpha1 AviatorPolicy but got %T", srcRaw)
+	}
+	in, err := popConversionData(&src.ObjectMeta, &dst.ObjectMeta)
+	if err != nil {
+		return err
+	}
+
+	if err := convertJSON(&src.Spec, &dst.Spec); err != nil {
+		return fmt.Errorf("converting spec: %w", err)
+	}
+	if err := convertJSON(&src.Status, &dst.Status); err != nil {
+		return fmt.Errorf("converting status: %w", err)
+	}
+
+	var out conversionData
+	out.Spec = specFromHub(&src.Spec, &dst.Spec, in.Spec)
+	if src.Status.EffectiveSpec != nil {
+		out.EffectiveSpec = specFromHub(src.Status.EffectiveSpec, dst.Status.EffectiveSpec, in.EffectiveSpec)
+	}
+	return pushConversionData(&dst.ObjectMeta, out)
+}
+
+// specToHub maps the fields v1alpha2 regrouped onto dst, which already
+// holds everything else. in is what a previous conversion from v1alpha1
+// recorded, if anything; the returned shape is what v1alpha1 cannot hold.
+func specToHub(src *AviatorPolicySpec, dst *v1alpha1.AviatorPolicySpec, in *specShape) *specShape {
+	dst.LatencyThreshold = src.Selection.Threshold
+	dst.LatencySource = v1alpha1.LatencySourceType(src.Source.Type)
+	var out *specShape
+	if probe := src.Source.Probe; probe != nil {
+		if probe.Port != nil {
+			port := *probe.Port
+			dst.TargetPort = &port
+		} else {
+			out = &specShape{EmptyProbe: true}
+		}
+	}
+
+	for i, o := range src.Schedules {
+		if o.Selection == nil {
+			continue
+		}
+		shape, recorded := scheduleShape{}, false
+		if in != nil {
+			shape, recorded = in.Schedules[o.Name]
+		}
+		// Without a record, a zero threshold was no latencyThreshold
+		// override, and a selection that only sets the threshold was a bare
+		// latencyThreshold override.
+		if !recorded && o.Selection.Threshold.Duration == 0 {
+			continue
+		}
+		threshold := o.Selection.Threshold
+		dst.Schedules[i].LatencyThreshold = &threshold
+		rest := *o.Selection
+		rest.Threshold = metav1.Duration{}
+		if (recorded && !shape.Selection) || (!recorded && rest == (SelectionSpec{})) {
+			dst.Schedules[i].Selection = nil
+		}
+	}
+	return out
+}
+
+// specFromHub maps the fields v1alpha2 regrouped onto dst, which already
+// holds everything else. in is what a previous conversion from v1alpha2
+// recorded, if anything; the returned shape is what v1alpha2 cannot hold.
+func specFromHub(src *v1alpha1.AviatorPolicySpec, dst *AviatorPolicySpec, in *specShape) *specShape {
+	dst.Selection.Threshold = src.LatencyThreshold
+	dst.Source.Type = LatencySourceType(src.LatencySource)
+	if src.TargetPort != nil {
+		port := *src.TargetPort
+		dst.Source.Probe = &ProbeSourceSpec{Port: &port}
+	} else if in != nil && in.EmptyProbe {
+		dst.Source.Probe = &ProbeSourceSpec{}
+	}
+
+	var out *specShape
+	for i, o := range src.Schedules {
+		if o.LatencyThreshold == nil {
+			continue
+		}
+		// specToHub cannot tell a zero threshold from none, nor an empty
+		// selection from none, so record them.
+		emptySelection := o.Selection != nil && *o.Selection == (v1alpha1.SelectionPolicy{})
+		if o.LatencyThreshold.Duration == 0 || emptySelection {
+			if out == nil {
+				out = &specShape{Schedules: make(map[string]scheduleShape)}
+			}
+			out.Schedules[o.Name] = scheduleShape{LatencyThreshold: true, Selection: o.Selection != nil}
+		}
+		if dst.Schedules[i].Selection == nil {
+			dst.Schedules[i].Selection = &SelectionSpec{}
+		}
+		dst.Schedules[i].Selection.Threshold = *o.LatencyThreshold
+	}
+	return out
+}
+
+// popConversionData copies src's metadata to dst without
+// conversionAnnotation, and returns what the annotation held.
+func popConversionData(src, dst *metav1.ObjectMeta) (conversionData, error) {
+	var data conversionData
+	*dst = *src
+	raw, ok := src.Annotations[conversionAnnotation]
+	if !ok {
+		return data, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return data, fmt.Errorf("parsing %s annotation: %w", conversionAnnotation, err)
+	}
+	dst.Annotations = make(map[string]string, len(src.Annotations)-1)
+	for k, v := range src.Annotations {
+		if k != conversionAnnotation {
+			dst.Annotations[k] = v
+		}
+	}
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+	return data, nil
+}
+
+// pushConversionData stores data in meta's conversionAnnotation, unless
+// there is nothing to record.
+func pushConversionData(meta *metav1.ObjectMeta, data conversionData) error {
+	if data.Spec == nil && data.EffectiveSpec == nil {
+		return nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	annotations := make(map[string]string, len(meta.Annotations)+1)
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	annotations[conversionAnnotation] = string(raw)
+	meta.Annotations = annotations
+	return nil
+}
+
+// convertJSON copies in to out through their JSON encoding, which is what
+// the API server stores and sends, so nothing representable is lost.
+func convertJSON(in, out any) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
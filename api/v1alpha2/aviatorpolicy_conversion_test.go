@@ -0,0 +1,285 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"aviator/api/v1alpha1"
+)
+
+func int32Ptr(v int32) *int32 { return &v }
+
+func duration(d time.Duration) metav1.Duration { return metav1.Duration{Duration: d} }
+
+// fullV1alpha1Policy sets every field of a v1alpha1 policy. Times are whole
+// seconds, as they are on the wire.
+func fullV1alpha1Policy() *v1alpha1.AviatorPolicy {
+	factor := resource.MustParse("2.5")
+	maxEject := int32Ptr(40)
+	now := metav1.NewTime(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	policy := &v1alpha1.AviatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "default",
+			Labels:      map[string]string{"team": "a"},
+			Annotations: map[string]string{"note": "x"},
+			Generation:  4,
+		},
+		Spec: v1alpha1.AviatorPolicySpec{
+			TargetRef: v1alpha1.TargetRef{
+				APIVersion: "v1",
+				Kind:       v1alpha1.TargetKindPod,
+				Name:       "web",
+				Selector:   &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			},
+			Mode:               v1alpha1.PolicyModeObserve,
+			LatencyThreshold:   duration(150 * time.Millisecond),
+			EvaluationInterval: duration(10 * time.Second),
+			Selection: v1alpha1.SelectionPolicy{
+				Mode:       v1alpha1.SelectionModeScore,
+				TopN:       int32Ptr(4),
+				Percentage: int32Ptr(60),
+				Outlier: &v1alpha1.OutlierDetection{
+					Method:     v1alpha1.OutlierMethodMAD,
+					Factor:     &factor,
+					MinSamples: int32Ptr(5),
+				},
+				Weights: &v1alpha1.ScoreWeights{P50: 1, P99: 2, ErrorRate: 3, CPU: 4, Memory: 5, Restarts: 6},
+			},
+			CircuitBreaker: &v1alpha1.CircuitBreakerSpec{
+				Enabled:               true,
+				P99Threshold:          duration(time.Second),
+				ErrorRateThreshold:    10,
+				ConsecutiveViolations: 2,
+				ConsecutiveFailures:   5,
+				EjectUnreachable:      true,
+				RecoveryInterval:      duration(time.Minute),
+			},
+			Dampening: &v1alpha1.DampeningSpec{
+				Enabled:              true,
+				ThresholdPercent:     30,
+				ConsecutiveIntervals: 2,
+				Hysteresis: &v1alpha1.HysteresisSpec{
+					ExitThreshold:  duration(300 * time.Millisecond),
+					EntryThreshold: duration(200 * time.Millisecond),
+					ExitIntervals:  2,
+					EntryIntervals: 4,
+				},
+			},
+			Topology:    &v1alpha1.TopologySpec{MinPodsPerZone: 2},
+			Guardrails:  &v1alpha1.GuardrailsSpec{MinActivePods: 2, MinActivePercent: 20, MaxEjectionPercent: maxEject},
+			Drain:       &v1alpha1.DrainSpec{Duration: duration(45 * time.Second)},
+			WarmUp:      &v1alpha1.WarmUpSpec{Mode: v1alpha1.WarmUpModeSlowStart, Duration: duration(time.Minute)},
+			Smoothing:   &v1alpha1.SmoothingSpec{Method: v1alpha1.SmoothingMethodWindowQuantile, HalfLife: duration(time.Second), Window: duration(time.Minute), Quantile: 90, MinSamples: 3},
+			Exploration: &v1alpha1.ExplorationSpec{Mode: v1alpha1.ExplorationModeProbe, EpsilonPercent: 5, Interval: duration(time.Minute), Duration: duration(time.Second)},
+			Routing:     &v1alpha1.RoutingSpec{Mode: v1alpha1.RoutingModeDerivedService, ServiceName: "web-fast"},
+			Schedules: []v1alpha1.ScheduledOverride{
+				{
+					ScheduleWindow:   v1alpha1.ScheduleWindow{Name: "night", Cron: "0 22 * * *", Duration: duration(8 * time.Hour)},
+					LatencyThreshold: &metav1.Duration{Duration: 300 * time.Millisecond},
+				},
+				{
+					ScheduleWindow:   v1alpha1.ScheduleWindow{Name: "peak", Cron: "0 9 * * mon-fri", Duration: duration(8 * time.Hour), TimeZone: "Europe/Berlin"},
+					LatencyThreshold: &metav1.Duration{Duration: 50 * time.Millisecond},
+					Selection:        &v1alpha1.SelectionPolicy{Mode: v1alpha1.SelectionModeThreshold},
+					CircuitBreaker:   &v1alpha1.CircuitBreakerSpec{Enabled: true, P99Threshold: duration(200 * time.Millisecond)},
+					Dampening:        &v1alpha1.DampeningSpec{Enabled: false},
+				},
+			},
+			Freezes:       []v1alpha1.ScheduleWindow{{Name: "deploys", Cron: "0 18 * * fri", Duration: duration(2 * time.Hour)}},
+			LatencySource: v1alpha1.LatencySourceProbe,
+			TargetPort:    int32Ptr(8080),
+		},
+		Status: v1alpha1.AviatorPolicyStatus{
+			ObservedGeneration: 4,
+			LastEvaluationTime: now,
+			ActivePods:         3,
+			TotalPods:          5,
+			AverageLatencyMs:   12,
+			P99LatencyMs:       40,
+			CircuitBrokenPods:  []string{"web-4"},
+			PodLatencies: []v1alpha1.PodLatencyInfo{{
+				Name: "web-1", PodIP: "10.0.0.1", Zone: "a",
+				P50: duration(time.Millisecond), P99: duration(5 * time.Millisecond),
+				CircuitBroken: true, CircuitBreakerCause: "ErrorRate",
+				Score: int32Ptr(120), ScoreBreakdown: map[string]int32{"p99": 120},
+			}},
+			UnmeasuredPods:      []v1alpha1.UnmeasuredPodInfo{{Name: "web-5", State: v1alpha1.UnmeasuredPodWarmingUp, ReadySince: now}},
+			ExploringPods:       []string{"web-3"},
+			DrainingPods:        []v1alpha1.DrainingPodInfo{{Name: "web-2", PodIP: "10.0.0.2", Since: now, Until: now}},
+			Observed:            &v1alpha1.ObservedRouting{SelectedPods: []string{"web-1"}, Added: []string{"web-1"}, Removed: []string{"web-2"}},
+			RoutedService:       "web-fast",
+			EndpointSliceWrites: v1alpha1.EndpointSliceWriteStats{Applied: 3, Skipped: 2, Conflicted: 1},
+			ActiveSchedules:     []string{"peak"},
+			FrozenUntil:         &now,
+			ManualOverrides:     []v1alpha1.ManualOverride{{Pod: "web-2", Type: v1alpha1.ManualOverridePin}},
+			CircuitBreakerReset: "2025-06-01",
+			ClusterPolicies:     []string{"baseline"},
+			Conditions: []metav1.Condition{{
+				Type: "Ready", Status: metav1.ConditionTrue, Reason: "Observing", LastTransitionTime: now,
+			}},
+		},
+	}
+	policy.Status.EffectiveSpec = policy.Spec.DeepCopy()
+	return policy
+}
+
+func TestRoundTripFromV1alpha1(t *testing.T) {
+	zero := func() *metav1.Duration { return &metav1.Duration{} }
+	tests := []struct {
+		name   string
+		mutate func(*v1alpha1.AviatorPolicy)
+	}{
+		{"every field set", func(*v1alpha1.AviatorPolicy) {}},
+		{"zero schedule threshold", func(p *v1alpha1.AviatorPolicy) {
+			p.Spec.Schedules[0].LatencyThreshold = zero()
+		}},
+		{"zero schedule threshold with a selection", func(p *v1alpha1.AviatorPolicy) {
+			p.Spec.Schedules[1].LatencyThreshold = zero()
+		}},
+		{"empty schedule selection with a threshold", func(p *v1alpha1.AviatorPolicy) {
+			p.Spec.Schedules[0].Selection = &v1alpha1.SelectionPolicy{}
+		}},
+		{"empty schedule selection without a threshold", func(p *v1alpha1.AviatorPolicy) {
+			p.Spec.Schedules[0].LatencyThreshold = nil
+			p.Spec.Schedules[0].Selection = &v1alpha1.SelectionPolicy{}
+		}},
+		{"no annotations", func(p *v1alpha1.AviatorPolicy) {
+			p.Annotations = nil
+			p.Spec.Schedules[0].LatencyThreshold = zero()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := fullV1alpha1Policy()
+			tt.mutate(original)
+			original.Status.EffectiveSpec = original.Spec.DeepCopy()
+
+			var spoke AviatorPolicy
+			if err := spoke.ConvertFrom(original.DeepCopy()); err != nil {
+				t.Fatalf("ConvertFrom: %v", err)
+			}
+			var hub v1alpha1.AviatorPolicy
+			if err := spoke.ConvertTo(&hub); err != nil {
+				t.Fatalf("ConvertTo: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(original, &hub) {
+				t.Errorf("round trip changed the policy:\n got %+v\nwant %+v", hub, *original)
+			}
+		})
+	}
+}
+
+func TestRoundTripFromV1alpha2(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*AviatorPolicy)
+	}{
+		{"probe without a port", func(p *AviatorPolicy) {
+			p.Spec.Source.Probe = &ProbeSourceSpec{}
+		}},
+		{"probe with a port", func(p *AviatorPolicy) {
+			p.Spec.Source.Probe = &ProbeSourceSpec{Port: int32Ptr(9090)}
+		}},
+		{"schedule selection with a zero threshold", func(p *AviatorPolicy) {
+			p.Spec.Schedules = []ScheduledOverride{{
+				ScheduleWindow: ScheduleWindow{Name: "night", Cron: "0 22 * * *", Duration: duration(time.Hour)},
+				Selection:      &SelectionSpec{Mode: SelectionModeTopN, TopN: int32Ptr(2), Threshold: duration(0)},
+			}}
+		}},
+		{"schedule selection with only a threshold", func(p *AviatorPolicy) {
+			p.Spec.Schedules = []ScheduledOverride{{
+				ScheduleWindow: ScheduleWindow{Name: "night", Cron: "0 22 * * *", Duration: duration(time.Hour)},
+				Selection:      &SelectionSpec{Threshold: duration(time.Second)},
+			}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := &AviatorPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec: AviatorPolicySpec{
+					TargetRef: TargetRef{Name: "web"},
+					Source:    LatencySourceSpec{Type: LatencySourceProbe},
+					Selection: SelectionSpec{Mode: SelectionModeThreshold, Threshold: duration(80 * time.Millisecond)},
+				},
+			}
+			tt.mutate(original)
+			original.Status.EffectiveSpec = original.Spec.DeepCopy()
+
+			var hub v1alpha1.AviatorPolicy
+			if err := original.DeepCopy().ConvertTo(&hub); err != nil {
+				t.Fatalf("ConvertTo: %v", err)
+			}
+			var spoke AviatorPolicy
+			if err := spoke.ConvertFrom(&hub); err != nil {
+				t.Fatalf("ConvertFrom: %v", err)
+			}
+
+			if !equality.Semantic.DeepEqual(original, &spoke) {
+				t.Errorf("round trip changed the policy:\n got %+v\nwant %+v", spoke, *original)
+			}
+		})
+	}
+}
+
+func TestConvertFromRegroupsFields(t *testing.T) {
+	var spoke AviatorPolicy
+	if err := spoke.ConvertFrom(fullV1alpha1Policy()); err != nil {
+		t.Fatal(err)
+	}
+	if got := spoke.Spec.Selection.Threshold.Duration; got != 150*time.Millisecond {
+		t.Errorf("selection.threshold = %s, want 150ms", got)
+	}
+	if spoke.Spec.Source.Type != LatencySourceProbe {
+		t.Errorf("source.type = %q, want probe", spoke.Spec.Source.Type)
+	}
+	if p := spoke.Spec.Source.Probe; p == nil || p.Port == nil || *p.Port != 8080 {
+		t.Errorf("source.probe = %+v, want port 8080", p)
+	}
+	if sel := spoke.Spec.Schedules[0].Selection; sel == nil || sel.Threshold.Duration != 300*time.Millisecond {
+		t.Errorf("schedules[0].selection = %+v, want threshold 300ms", sel)
+	}
+	if eff := spoke.Status.EffectiveSpec; eff == nil || eff.Selection.Threshold.Duration != 150*time.Millisecond {
+		t.Errorf("status.effectiveSpec = %+v, want selection.threshold 150ms", eff)
+	}
+	if spoke.Spec.Selection.Mode != SelectionModeScore || spoke.Spec.CircuitBreaker == nil {
+		t.Errorf("unchanged fields were not copied: %+v", spoke.Spec)
+	}
+}
+
+func TestConvertToWithoutProbe(t *testing.T) {
+	spoke := AviatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web"},
+		Spec: AviatorPolicySpec{
+			TargetRef: TargetRef{Name: "web"},
+			Source:    LatencySourceSpec{Type: LatencySourceEBPF},
+			Selection: SelectionSpec{Mode: SelectionModeThreshold, Threshold: duration(80 * time.Millisecond)},
+		},
+	}
+	var hub v1alpha1.AviatorPolicy
+	if err := spoke.ConvertTo(&hub); err != nil {
+		t.Fatal(err)
+	}
+	if hub.Spec.TargetPort != nil {
+		t.Errorf("targetPort = %d, want unset", *hub.Spec.TargetPort)
+	}
+	if hub.Spec.LatencyThreshold.Duration != 80*time.Millisecond || hub.Spec.LatencySource != v1alpha1.LatencySourceEBPF {
+		t.Errorf("got latencyThreshold %s, latencySource %q", hub.Spec.LatencyThreshold.Duration, hub.Spec.LatencySource)
+	}
+}
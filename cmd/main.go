@@ -38,9 +38,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	aviatorv1alpha1 "aviator/api/v1alpha1"
+	aviatorv1alpha2 "aviator/api/v1alpha2"
 	"aviator/internal/controller"
 	"aviator/internal/endpointslice"
 	"aviator/internal/latency"
+	webhookaviatorv1alpha1 "aviator/internal/webhook/v1alpha1"
+	webhookaviatorv1alpha2 "aviator/internal/webhook/v1alpha2"
+	"aviator/internal/xds"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -52,6 +56,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(aviatorv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(aviatorv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -66,6 +71,8 @@ func main() {
 	var enableHTTP2 bool
 	var latencySourceType string
 	var probePort int
+	var maxEndpointsPerSlice int
+	var xdsAddr string
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -89,6 +96,10 @@ func main() {
 		"Latency data source: 'ebpf' (requires DaemonSet agents) or 'probe' (HTTP health check fallback)")
 	flag.IntVar(&probePort, "probe-port", 8080,
 		"Default port for HTTP probe-based latency measurement (used when latency-source=probe)")
+	flag.IntVar(&maxEndpointsPerSlice, "max-endpoints-per-slice", endpointslice.DefaultMaxEndpointsPerSlice,
+		"Maximum number of endpoints in each Aviator-managed EndpointSlice (at most 1000)")
+	flag.StringVar(&xdsAddr, "xds-bind-address", "0",
+		"The address the Envoy xDS (EDS) server binds to, e.g. :18000. Leave as 0 to disable it.")
 
 	opts := zap.Options{
 		Development: true,
@@ -190,6 +201,7 @@ func main() {
 
 	// Initialize EndpointSlice manager.
 	esManager := endpointslice.NewManager(mgr.GetClient(), ctrl.Log)
+	esManager.MaxEndpointsPerSlice = maxEndpointsPerSlice
 
 	// Create and register the reconciler.
 	reconciler := controller.NewReconciler(
@@ -198,10 +210,29 @@ func main() {
 		latSource,
 		esManager,
 	)
+	if xdsAddr != "0" && xdsAddr != "" {
+		xdsServer := xds.NewServer(xdsAddr, ctrl.Log)
+		if err := mgr.Add(xdsServer); err != nil {
+			setupLog.Error(err, "unable to add xDS server to manager")
+			os.Exit(1)
+		}
+		reconciler.XDS = xdsServer
+	}
 	if err = reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "AviatorPolicy")
 		os.Exit(1)
 	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookaviatorv1alpha1.SetupAviatorPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AviatorPolicy")
+			os.Exit(1)
+		}
+		if err = webhookaviatorv1alpha2.SetupAviatorPolicyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "AviatorPolicy")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
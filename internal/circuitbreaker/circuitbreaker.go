@@ -37,43 +37,100 @@ func (s State) String() string {
 	}
 }
 
+// Cause names the condition behind a pod's latest state transition.
+type Cause string
+
+const (
+	CauseLatency             Cause = "Latency"             // P99 above the threshold.
+	CauseErrorRate           Cause = "ErrorRate"           // Error rate at or above the threshold.
+	CauseConsecutiveFailures Cause = "ConsecutiveFailures" // Too many failed probes in a row.
+	CauseUnreachable         Cause = "Unreachable"         // No probe could connect.
+	CauseForced              Cause = "Forced"              // Ejected by ForceEject.
+	CauseRecoveryInterval    Cause = "RecoveryInterval"    // Recovery interval elapsed; probing.
+	CauseRecovered           Cause = "Recovered"           // Healthy again while probed.
+)
+
 // PodState tracks the circuit breaker state for a single pod.
 type PodState struct {
 	State               State
+	Cause               Cause
 	ViolationCount      int32
+	ConsecutiveFailures int64
 	LastViolationTime   time.Time
 	EjectedAt           time.Time
 	LastRecoveryProbeAt time.Time
 }
 
+// Observation is one evaluation's measurement of a pod.
+type Observation struct {
+	P99 time.Duration
+	// ErrorRate is the fraction of failed samples (0–1).
+	ErrorRate float64
+	// TrailingFailures counts the failed samples after the last successful
+	// one. AllFailed means no sample succeeded, so the run of failures
+	// carries on from the previous observation.
+	TrailingFailures int64
+	AllFailed        bool
+	// Unreachable means no sample could connect to the pod.
+	Unreachable bool
+}
+
 // Breaker manages circuit breaker state for a set of pods.
 type Breaker struct {
 	mu                    sync.RWMutex
 	pods                  map[string]*PodState // keyed by pod IP
 	p99Threshold          time.Duration
+	errorRateThreshold    float64
 	consecutiveViolations int32
+	consecutiveFailures   int64
+	ejectUnreachable      bool
 	recoveryInterval      time.Duration
 }
 
 // Config holds circuit breaker parameters.
 type Config struct {
-	P99Threshold          time.Duration
+	P99Threshold time.Duration
+	// ErrorRateThreshold is the error rate (0–1) at or above which an
+	// observation is a violation. Zero disables it.
+	ErrorRateThreshold    float64
 	ConsecutiveViolations int32
-	RecoveryInterval      time.Duration
+	// ConsecutiveFailures failed samples in a row eject a pod at once. Zero
+	// disables it.
+	ConsecutiveFailures int64
+	// EjectUnreachable ejects a pod at once when it is unreachable.
+	EjectUnreachable bool
+	RecoveryInterval time.Duration
 }
 
 // New creates a new circuit breaker with the given configuration.
 func New(cfg Config) *Breaker {
-	return &Breaker{
-		pods:                  make(map[string]*PodState),
-		p99Threshold:          cfg.P99Threshold,
-		consecutiveViolations: cfg.ConsecutiveViolations,
-		recoveryInterval:      cfg.RecoveryInterval,
-	}
+	b := &Breaker{pods: make(map[string]*PodState)}
+	b.Configure(cfg)
+	return b
+}
+
+// Configure replaces the breaker's parameters, keeping each pod's state.
+func (b *Breaker) Configure(cfg Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.p99Threshold = cfg.P99Threshold
+	b.errorRateThreshold = cfg.ErrorRateThreshold
+	b.consecutiveViolations = cfg.ConsecutiveViolations
+	b.consecutiveFailures = cfg.ConsecutiveFailures
+	b.ejectUnreachable = cfg.EjectUnreachable
+	b.recoveryInterval = cfg.RecoveryInterval
 }
 
 // RecordLatency records a latency observation for a pod and transitions state.
 func (b *Breaker) RecordLatency(podIP string, p99 time.Duration) {
+	b.Record(podIP, Observation{P99: p99})
+}
+
+// Record records an observation for a pod and transitions state. An
+// unreachable pod or a long enough run of failures ejects a pod at once;
+// high latency and error rates eject it after consecutive violations. The
+// pod's Cause records the condition behind each transition.
+func (b *Breaker) Record(podIP string, obs Observation) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -82,17 +139,27 @@ func (b *Breaker) RecordLatency(podIP string, p99 time.Duration) {
 		ps = &PodState{State: StateClosed}
 		b.pods[podIP] = ps
 	}
+	if obs.AllFailed {
+		ps.ConsecutiveFailures += obs.TrailingFailures
+	} else {
+		ps.ConsecutiveFailures = obs.TrailingFailures
+	}
+
+	trip := b.tripCause(ps, obs)
+	violation := b.violationCause(obs)
 
 	switch ps.State {
 	case StateClosed:
-		if p99 > b.p99Threshold {
+		switch {
+		case trip != "":
+			b.eject(ps, trip)
+		case violation != "":
 			ps.ViolationCount++
 			ps.LastViolationTime = time.Now()
 			if ps.ViolationCount >= b.consecutiveViolations {
-				ps.State = StateOpen
-				ps.EjectedAt = time.Now()
+				b.eject(ps, violation)
 			}
-		} else {
+		default:
 			ps.ViolationCount = 0
 		}
 
@@ -100,21 +167,73 @@ func (b *Breaker) RecordLatency(podIP string, p99 time.Duration) {
 		// Pod is ejected. Check if recovery interval has elapsed.
 		if time.Since(ps.EjectedAt) >= b.recoveryInterval {
 			ps.State = StateHalfOpen
+			ps.Cause = CauseRecoveryInterval
 			ps.LastRecoveryProbeAt = time.Now()
 		}
 
 	case StateHalfOpen:
 		// Pod is being probed for recovery.
-		if p99 <= b.p99Threshold {
+		switch {
+		case trip != "":
+			b.eject(ps, trip)
+		case violation != "":
+			b.eject(ps, violation)
+		default:
 			ps.State = StateClosed
+			ps.Cause = CauseRecovered
 			ps.ViolationCount = 0
-		} else {
-			ps.State = StateOpen
-			ps.EjectedAt = time.Now()
 		}
 	}
 }
 
+// tripCause returns the condition that ejects the pod at once, if any.
+func (b *Breaker) tripCause(ps *PodState, obs Observation) Cause {
+	switch {
+	case b.ejectUnreachable && obs.Unreachable:
+		return CauseUnreachable
+	case b.consecutiveFailures > 0 && ps.ConsecutiveFailures >= b.consecutiveFailures:
+		return CauseConsecutiveFailures
+	}
+	return ""
+}
+
+// violationCause returns the condition that makes the observation a
+// violation, if any.
+func (b *Breaker) violationCause(obs Observation) Cause {
+	switch {
+	case b.errorRateThreshold > 0 && obs.ErrorRate >= b.errorRateThreshold:
+		return CauseErrorRate
+	case obs.P99 > b.p99Threshold:
+		return CauseLatency
+	}
+	return ""
+}
+
+func (b *Breaker) eject(ps *PodState, cause Cause) {
+	ps.State = StateOpen
+	ps.Cause = cause
+	ps.EjectedAt = time.Now()
+}
+
+// ForceEject ejects a pod regardless of its latency. The pod recovers as
+// usual once the recovery interval has passed since the last call, so
+// calling it every evaluation keeps the pod ejected. It reports whether the
+// pod was not already ejected.
+func (b *Breaker) ForceEject(podIP string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ps, ok := b.pods[podIP]
+	if !ok {
+		ps = &PodState{}
+		b.pods[podIP] = ps
+	}
+	wasOpen := ps.State == StateOpen
+	b.eject(ps, CauseForced)
+	ps.ViolationCount = 0
+	return !wasOpen
+}
+
 // IsEjected returns true if the pod should not receive traffic.
 func (b *Breaker) IsEjected(podIP string) bool {
 	b.mu.RLock()
@@ -162,6 +281,7 @@ func (b *Breaker) CheckRecovery() []string {
 	for ip, ps := range b.pods {
 		if ps.State == StateOpen && time.Since(ps.EjectedAt) >= b.recoveryInterval {
 			ps.State = StateHalfOpen
+			ps.Cause = CauseRecoveryInterval
 			ps.LastRecoveryProbeAt = time.Now()
 			transitioned = append(transitioned, ip)
 		}
@@ -182,3 +302,19 @@ func (b *Breaker) Reset() {
 	defer b.mu.Unlock()
 	b.pods = make(map[string]*PodState)
 }
+
+// CapEjections limits ejections to maxPercent of total pods, rounding down.
+// ejected must be ordered from least to most severe (e.g. by ascending P99);
+// the most severe ejections are enforced and the rest are released back into
+// rotation. A negative maxPercent disables the cap.
+func CapEjections(ejected []string, total int, maxPercent int) (enforced, released []string) {
+	if maxPercent < 0 {
+		return ejected, nil
+	}
+	limit := (total * maxPercent) / 100
+	if len(ejected) <= limit {
+		return ejected, nil
+	}
+	cut := len(ejected) - limit
+	return ejected[cut:], ejected[:cut]
+}
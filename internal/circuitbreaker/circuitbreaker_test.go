@@ -114,3 +114,160 @@ func TestReset(t *testing.T) {
 		t.Error("reset should clear all state")
 	}
 }
+
+func TestCapEjections(t *testing.T) {
+	// Ordered from least to most severe.
+	ejected := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+
+	enforced, released := CapEjections(ejected, 10, 20)
+	if len(enforced) != 2 || enforced[0] != "10.0.0.2" || enforced[1] != "10.0.0.3" {
+		t.Errorf("expected the 2 most severe ejections enforced, got %v", enforced)
+	}
+	if len(released) != 1 || released[0] != "10.0.0.1" {
+		t.Errorf("expected 10.0.0.1 released, got %v", released)
+	}
+
+	enforced, released = CapEjections(ejected, 10, 50)
+	if len(enforced) != 3 || len(released) != 0 {
+		t.Errorf("expected no cap within limit, got enforced=%v released=%v", enforced, released)
+	}
+
+	enforced, released = CapEjections(ejected, 10, -1)
+	if len(enforced) != 3 || len(released) != 0 {
+		t.Errorf("expected cap disabled, got enforced=%v released=%v", enforced, released)
+	}
+}
+
+func TestConfigureKeepsState(t *testing.T) {
+	b := newTestBreaker()
+	b.RecordLatency("10.0.0.1", 200*time.Millisecond)
+	b.RecordLatency("10.0.0.1", 200*time.Millisecond)
+
+	// A lower threshold counts the same latency as a third violation.
+	b.Configure(Config{P99Threshold: 150 * time.Millisecond, ConsecutiveViolations: 3, RecoveryInterval: time.Second})
+	b.RecordLatency("10.0.0.1", 160*time.Millisecond)
+	if !b.IsEjected("10.0.0.1") {
+		t.Error("violations recorded before reconfiguring should count")
+	}
+
+	b.Configure(Config{P99Threshold: time.Second, ConsecutiveViolations: 3, RecoveryInterval: time.Second})
+	if !b.IsEjected("10.0.0.1") {
+		t.Error("reconfiguring should not re-admit an ejected pod")
+	}
+}
+
+func TestForceEject(t *testing.T) {
+	b := newTestBreaker()
+	b.RecordLatency("10.0.0.1", 50*time.Millisecond)
+
+	if !b.ForceEject("10.0.0.1") {
+		t.Error("first ForceEject should report a transition")
+	}
+	if b.ForceEject("10.0.0.1") {
+		t.Error("ForceEject of an ejected pod should not report a transition")
+	}
+	if !b.IsEjected("10.0.0.1") {
+		t.Error("pod should be ejected")
+	}
+	// Good latency does not re-admit a pod before the recovery interval.
+	b.RecordLatency("10.0.0.1", 50*time.Millisecond)
+	if !b.IsEjected("10.0.0.1") {
+		t.Error("pod should stay ejected until the recovery interval passes")
+	}
+}
+
+func TestEjectionCause(t *testing.T) {
+	b := newTestBreaker()
+	for i := 0; i < 3; i++ {
+		b.RecordLatency("10.0.0.1", 200*time.Millisecond)
+	}
+	if state, _ := b.GetState("10.0.0.1"); state.Cause != CauseLatency {
+		t.Errorf("cause = %q, want %q", state.Cause, CauseLatency)
+	}
+	b.ForceEject("10.0.0.2")
+	if state, _ := b.GetState("10.0.0.2"); state.Cause != CauseForced {
+		t.Errorf("cause = %q, want %q", state.Cause, CauseForced)
+	}
+}
+
+func TestErrorRateEjection(t *testing.T) {
+	b := New(Config{
+		P99Threshold:          100 * time.Millisecond,
+		ErrorRateThreshold:    0.5,
+		ConsecutiveViolations: 2,
+		RecoveryInterval:      time.Millisecond,
+	})
+	fastErrors := Observation{P99: 5 * time.Millisecond, ErrorRate: 1}
+
+	b.Record("10.0.0.1", fastErrors)
+	if b.IsEjected("10.0.0.1") {
+		t.Fatal("one violation should not eject")
+	}
+	b.Record("10.0.0.1", fastErrors)
+	state, _ := b.GetState("10.0.0.1")
+	if state.State != StateOpen || state.Cause != CauseErrorRate {
+		t.Fatalf("state = %s (%s), want open (ErrorRate)", state.State, state.Cause)
+	}
+
+	// A pod still failing while probed is ejected again; a healthy one
+	// recovers.
+	time.Sleep(2 * time.Millisecond)
+	b.CheckRecovery()
+	b.Record("10.0.0.1", fastErrors)
+	if !b.IsEjected("10.0.0.1") {
+		t.Fatal("a half-open pod with errors should be ejected again")
+	}
+	time.Sleep(2 * time.Millisecond)
+	b.CheckRecovery()
+	b.Record("10.0.0.1", Observation{P99: 5 * time.Millisecond, ErrorRate: 0.1})
+	if state, _ := b.GetState("10.0.0.1"); state.State != StateClosed || state.Cause != CauseRecovered {
+		t.Errorf("state = %s (%s), want closed (Recovered)", state.State, state.Cause)
+	}
+}
+
+func TestConsecutiveFailuresEjection(t *testing.T) {
+	b := New(Config{
+		P99Threshold:          time.Second,
+		ConsecutiveViolations: 10,
+		ConsecutiveFailures:   4,
+		RecoveryInterval:      time.Second,
+	})
+
+	// Two failures at the end of a round, then a round with no success:
+	// the run carries over.
+	b.Record("10.0.0.1", Observation{TrailingFailures: 2})
+	b.Record("10.0.0.1", Observation{TrailingFailures: 1, AllFailed: true})
+	if b.IsEjected("10.0.0.1") {
+		t.Fatal("three failures in a row should not eject")
+	}
+	b.Record("10.0.0.1", Observation{TrailingFailures: 1, AllFailed: true})
+	state, _ := b.GetState("10.0.0.1")
+	if state.State != StateOpen || state.Cause != CauseConsecutiveFailures {
+		t.Errorf("state = %s (%s), want open (ConsecutiveFailures)", state.State, state.Cause)
+	}
+
+	// A success in the round breaks the run.
+	b.Record("10.0.0.2", Observation{TrailingFailures: 3, AllFailed: true})
+	b.Record("10.0.0.2", Observation{TrailingFailures: 1})
+	if state, _ := b.GetState("10.0.0.2"); state.ConsecutiveFailures != 1 || b.IsEjected("10.0.0.2") {
+		t.Errorf("run = %d, ejected %v; want 1 and not ejected", state.ConsecutiveFailures, b.IsEjected("10.0.0.2"))
+	}
+}
+
+func TestUnreachableEjection(t *testing.T) {
+	cfg := Config{P99Threshold: time.Second, ConsecutiveViolations: 3, RecoveryInterval: time.Second}
+	unreachable := Observation{P99: 500 * time.Millisecond, Unreachable: true, AllFailed: true}
+
+	b := New(cfg)
+	b.Record("10.0.0.1", unreachable)
+	if b.IsEjected("10.0.0.1") {
+		t.Fatal("unreachable pods should only be ejected at once when enabled")
+	}
+
+	cfg.EjectUnreachable = true
+	b.Configure(cfg)
+	b.Record("10.0.0.1", unreachable)
+	if state, _ := b.GetState("10.0.0.1"); state.State != StateOpen || state.Cause != CauseUnreachable {
+		t.Errorf("state = %s (%s), want open (Unreachable)", state.State, state.Cause)
+	}
+}
@@ -19,23 +19,29 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	aviatorv1alpha1 "aviator/api/v1alpha1"
 	"aviator/internal/circuitbreaker"
 	"aviator/internal/endpointslice"
 	"aviator/internal/latency"
+	"aviator/internal/podmetrics"
+	"aviator/internal/xds"
 
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -44,6 +50,30 @@ const (
 	defaultTargetPort   = int32(8080)
 	defaultPercentage   = int32(50)
 	maxStatusPodEntries = 10
+
+	defaultOutlierFactor     = 3.0
+	defaultOutlierMinSamples = int32(3)
+
+	// Defaults for durations left at zero, matching the CRD schema defaults
+	// for policies stored before the schema applied them.
+	defaultWarmUpDuration      = 60 * time.Second
+	defaultExplorationEpsilon  = 0.1
+	defaultExplorationInterval = 60 * time.Second
+	defaultExplorationDuration = 10 * time.Second
+	defaultSmoothingHalfLife   = 30 * time.Second
+	defaultSmoothingWindow     = 60 * time.Second
+	defaultSmoothingQuantile   = 50
+	defaultDrainDuration       = 30 * time.Second
+
+	// minDrainRequeue is the shortest requeue delay waiting for a drain to
+	// end.
+	minDrainRequeue = time.Second
+
+	// explorationProbeTimeout bounds a background exploration probe round.
+	explorationProbeTimeout = 30 * time.Second
+
+	// scoreScale converts composite scores in [0, 1] to status integers.
+	scoreScale = 1000
 )
 
 // AviatorPolicyReconciler reconciles AviatorPolicy objects.
@@ -57,9 +87,18 @@ type AviatorPolicyReconciler struct {
 	// EndpointSliceManager handles EndpointSlice CRUD operations.
 	EndpointSliceManager *endpointslice.Manager
 
+	// Recorder emits Events on policies. Set by SetupWithManager if nil.
+	Recorder record.EventRecorder
+
+	// XDS, if set, serves each policy's routing to Envoy over EDS.
+	XDS *xds.Server
+
 	// Per-policy state (keyed by policy NamespacedName).
-	breakers   map[string]*circuitbreaker.Breaker
-	dampeners  map[string]*latency.DampeningState
+	breakers  map[string]*circuitbreaker.Breaker
+	dampeners map[string]*latency.DampeningState
+	explorers map[string]*latency.Explorer
+	smoothers map[string]*latency.Smoother
+	drainers  map[string]*endpointslice.DrainTracker
 }
 
 // NewReconciler creates a new AviatorPolicyReconciler.
@@ -76,16 +115,27 @@ func NewReconciler(
 		EndpointSliceManager: esManager,
 		breakers:             make(map[string]*circuitbreaker.Breaker),
 		dampeners:            make(map[string]*latency.DampeningState),
+		explorers:            make(map[string]*latency.Explorer),
+		smoothers:            make(map[string]*latency.Smoother),
+		drainers:             make(map[string]*endpointslice.DrainTracker),
 	}
 }
 
 // +kubebuilder:rbac:groups=aviator.example.com,resources=aviatorpolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=aviator.example.com,resources=aviatorpolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=aviator.example.com,resources=aviatorpolicies/finalizers,verbs=update
-// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups=aviator.example.com,resources=aviatorpolicyreports,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=aviator.example.com,resources=clusteraviatorpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=argoproj.io,resources=rollouts,verbs=get;list;watch
 // +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile evaluates pod latency and updates EndpointSlices for the target Service.
 func (r *AviatorPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -110,32 +160,76 @@ func (r *AviatorPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
-	// 4. Fetch the target Service.
+	// Inherit from cluster policies, overlay open schedules on the spec,
+	// hold the result to the cluster limits and check for a freeze.
+	clusterPolicies, err := r.matchClusterPolicies(ctx, req.Namespace)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing cluster policies: %w", err)
+	}
+	applyClusterDefaults(&policy, clusterPolicies)
+	now := time.Now()
+	r.applySchedules(&policy, now)
+	r.recordClusterPolicies(&policy, clusterPolicies, r.applyClusterLimits(&policy, clusterPolicies))
+	policy.Status.EffectiveSpec = policy.Spec.DeepCopy()
+	frozenUntil, frozen := r.checkFreeze(&policy, now)
+
+	// 4. Resolve the target to a Service and the selector of its pods.
 	var service corev1.Service
-	serviceKey := types.NamespacedName{
+	var selector labels.Selector
+	targetKey := types.NamespacedName{
 		Name:      policy.Spec.TargetRef.Name,
 		Namespace: req.Namespace,
 	}
-	if err := r.Get(ctx, serviceKey, &service); err != nil {
-		if errors.IsNotFound(err) {
-			logger.Info("target Service not found", "service", serviceKey)
-			r.setCondition(&policy, "Ready", metav1.ConditionFalse, "ServiceNotFound", "Target Service does not exist")
+	if targetsService(&policy) {
+		if err := r.Get(ctx, targetKey, &service); err != nil {
+			if errors.IsNotFound(err) {
+				logger.Info("target Service not found", "service", targetKey)
+				r.setCondition(&policy, "Ready", metav1.ConditionFalse, "ServiceNotFound", "Target Service does not exist")
+				_ = r.Status().Update(ctx, &policy)
+			}
+			return ctrl.Result{}, client.IgnoreNotFound(err)
+		}
+		set, err := serviceSelector(&service)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		// A selectorless Service has no pods of its own.
+		if len(set) > 0 {
+			selector = labels.SelectorFromSet(set)
+		}
+	} else {
+		sel, err := r.targetSelector(ctx, &policy)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				logger.Info("target not found", "kind", policy.Spec.TargetRef.Kind, "name", targetKey)
+				r.setCondition(&policy, "Ready", metav1.ConditionFalse, "TargetNotFound",
+					fmt.Sprintf("Target %s does not exist", policy.Spec.TargetRef.Kind))
+				_ = r.Status().Update(ctx, &policy)
+				return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
+			}
+			r.setCondition(&policy, "Ready", metav1.ConditionFalse, "InvalidTarget", err.Error())
 			_ = r.Status().Update(ctx, &policy)
+			return ctrl.Result{}, err
 		}
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		selector = sel
 	}
 
-	// 5. Fetch all pods behind the Service.
-	pods, err := r.getPodsForService(ctx, service)
+	// 5. Fetch all pods behind the target.
+	pods, terminating, err := r.getPods(ctx, req.Namespace, selector, service.Spec.PublishNotReadyAddresses)
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("listing pods: %w", err)
 	}
 	if len(pods) == 0 {
-		logger.Info("no pods found for Service", "service", serviceKey)
-		r.setCondition(&policy, "Ready", metav1.ConditionFalse, "NoPodsFound", "No pods match the Service selector")
+		logger.Info("no ready pods found for target", "target", targetKey)
+		r.setCondition(&policy, "Ready", metav1.ConditionFalse, "NoPodsFound", "No ready pods match the target selector")
 		_ = r.Status().Update(ctx, &policy)
 		return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
 	}
+	if !targetsService(&policy) {
+		// Workload targets are fronted by a Service the policy owns;
+		// ensureRouting persists it.
+		service = *frontingService(&policy, pods)
+	}
 
 	// 6. Collect pod IPs.
 	podIPMap := make(map[string]corev1.Pod, len(pods))
@@ -147,14 +241,27 @@ func (r *AviatorPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		}
 	}
 
+	nodeZones := r.getNodeZones(ctx, append(pods, terminating...))
+	overrides := readOverrides(&policy, podIPs, podIPMap)
+
 	// 7. Measure latency.
-	latencies, err := r.LatencySource.GetLatencies(ctx, podIPs)
+	probePorts := probePorts(&policy, &service, pods)
+	latencies, err := r.LatencySource.GetLatencies(ctx, podIPs, probePorts)
 	if err != nil {
 		logger.Error(err, "failed to get latencies")
+		if !meta.IsStatusConditionFalse(policy.Status.Conditions, "SourceReady") {
+			r.eventf(&policy, corev1.EventTypeWarning, "LatencyFetchFailed", "Fetching latencies from %s: %v",
+				r.LatencySource.Name(), err)
+		}
+		r.setCondition(&policy, "SourceReady", metav1.ConditionFalse, "LatencyFetchFailed", err.Error())
 		r.setCondition(&policy, "Ready", metav1.ConditionFalse, "LatencyFetchFailed", err.Error())
 		_ = r.Status().Update(ctx, &policy)
 		return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
 	}
+	r.setCondition(&policy, "SourceReady", metav1.ConditionTrue, "LatenciesFetched",
+		fmt.Sprintf("%s source measured %d of %d pods", r.LatencySource.Name(), len(latencies), len(podIPs)))
+	policyKey := req.NamespacedName.String()
+	latencies = r.smoothLatencies(&policy, policyKey, latencies)
 
 	// 8. Build rankings.
 	rankings := make([]latency.PodRanking, 0, len(latencies))
@@ -167,75 +274,305 @@ func (r *AviatorPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			PodName: pod.Name,
 			PodIP:   ip,
 			Stats:   stats,
+			Zone:    nodeZones[pod.Spec.NodeName],
 		})
 	}
-	rankings = latency.RankPods(rankings)
+	if sm := policy.Spec.Smoothing; sm != nil {
+		rankings = latency.ShrinkLowConfidence(rankings, int64(sm.MinSamples))
+	}
+	warmUp := r.planWarmUp(&policy, podIPs, podIPMap, latencies, nodeZones, rankings)
+	rankings = append(rankings, warmUp.ranked...)
+	policy.Status.UnmeasuredPods = warmUp.status
+	if policy.Spec.Selection.Mode == aviatorv1alpha1.SelectionModeScore {
+		rankings = r.scorePods(ctx, &policy, podIPMap, rankings)
+	} else {
+		rankings = latency.RankPods(rankings)
+	}
+	ev := evaluation{
+		podIPs:    podIPs,
+		podIPMap:  podIPMap,
+		zones:     nodeZones,
+		ranked:    rankings,
+		excluded:  make(map[string]string),
+		overrides: overrides.byPod(),
+	}
+	// Manually excluded pods are ranked for the report, then dropped.
+	for ip := range overrides.excluded {
+		ev.exclude(ip, aviatorv1alpha1.ExclusionManual)
+	}
+	rankings = overrides.withoutExcluded(rankings)
+	warmUp.included = overrides.withoutExcluded(warmUp.included)
 
 	// 9. Circuit breaker processing.
-	policyKey := req.NamespacedName.String()
+	measured := rankings
+	var guardrailNotes []string
+	var fallbacks []fallback
+	ejectedCount := 0
 	breaker := r.getOrCreateBreaker(&policy, policyKey)
+	ev.breaker = breaker
+	r.resetBreaker(&policy, breaker)
 	if breaker != nil {
 		breaker.CheckRecovery()
 		for _, rank := range rankings {
-			breaker.RecordLatency(rank.PodIP, rank.Stats.P99)
+			if !rank.Estimated {
+				before, _ := breaker.GetState(rank.PodIP)
+				breaker.Record(rank.PodIP, breakerObservation(rank.Stats))
+				after, _ := breaker.GetState(rank.PodIP)
+				r.recordBreakerTransition(&policy, podIPMap[rank.PodIP], rank, before, after)
+			}
+		}
+		for ip := range overrides.forceEject {
+			if breaker.ForceEject(ip) {
+				r.eventf(&policy, corev1.EventTypeWarning, "PodForceEjected",
+					"Pod %s ejected by the %s annotation", podIPMap[ip].Name, aviatorv1alpha1.ForceEjectAnnotation)
+			}
+		}
+		// Rankings are best-first, so ejected is ordered least to most
+		// severe. Forced ejections go last, so caps release them last.
+		var ejected, forced []string
+		for _, rank := range rankings {
+			if !breaker.IsEjected(rank.PodIP) {
+				continue
+			}
+			if overrides.forceEject[rank.PodIP] {
+				forced = append(forced, rank.PodIP)
+			} else {
+				ejected = append(ejected, rank.PodIP)
+			}
+		}
+		ejected = append(ejected, forced...)
+		if g := policy.Spec.Guardrails; g != nil && g.MaxEjectionPercent != nil {
+			var released []string
+			ejected, released = circuitbreaker.CapEjections(ejected, len(rankings), int(*g.MaxEjectionPercent))
+			if len(released) > 0 {
+				guardrailNotes = append(guardrailNotes, fmt.Sprintf(
+					"maxEjectionPercent kept %d ejected pod(s) in rotation", len(released)))
+			}
 		}
 		// Filter out ejected pods.
+		excluded := make(map[string]bool, len(ejected))
+		for _, ip := range ejected {
+			excluded[ip] = true
+		}
 		var healthy []latency.PodRanking
 		for _, rank := range rankings {
-			if !breaker.IsEjected(rank.PodIP) {
+			if !excluded[rank.PodIP] {
 				healthy = append(healthy, rank)
 			}
 		}
+		ejectedCount = len(ejected)
 		if len(healthy) > 0 {
 			rankings = healthy
+			for _, ip := range ejected {
+				ev.exclude(ip, aviatorv1alpha1.ExclusionCircuitBroken)
+			}
+		} else if len(ejected) > 0 {
+			// All pods are ejected; keep all of them as a fallback.
+			fallbacks = append(fallbacks, fallback{"AllPodsEjected",
+				fmt.Sprintf("all %d pods are ejected by the circuit breaker; routing to all of them", len(ejected))})
+		}
+	}
+	ev.ejected = ejectedCount
+	overridesChanged := r.recordOverrides(&policy, overrides, podIPMap)
+	r.setDegradedCondition(&policy, ejectedCount, len(measured))
+
+	// Per-pod hysteresis.
+	dampener := r.getOrCreateDampener(policyKey)
+	if d := policy.Spec.Dampening; d != nil && d.Enabled && d.Hysteresis != nil {
+		active := dampener.FilterHysteresis(rankings, latency.HysteresisConfig{
+			EntryThreshold: d.Hysteresis.EntryThreshold.Duration,
+			ExitThreshold:  d.Hysteresis.ExitThreshold.Duration,
+			EntryIntervals: int(d.Hysteresis.EntryIntervals),
+			ExitIntervals:  int(d.Hysteresis.ExitIntervals),
+		})
+		if len(active) > 0 {
+			held := make(map[string]bool, len(rankings))
+			for _, rank := range rankings {
+				held[rank.PodIP] = true
+			}
+			for _, rank := range active {
+				delete(held, rank.PodIP)
+			}
+			for ip := range held {
+				ev.exclude(ip, aviatorv1alpha1.ExclusionHysteresis)
+			}
+			rankings = active
+		} else if len(rankings) > 0 {
+			// No pod is active; keep all candidates as a fallback.
+			fallbacks = append(fallbacks, fallback{"NoPodPassedHysteresis",
+				"every pod is held out by hysteresis; routing to all candidates"})
 		}
-		// else: if all pods are ejected, use all pods as fallback
 	}
 
 	// 10. Select pods based on policy.
 	selected := r.selectPods(&policy, rankings)
+	if policy.Spec.Selection.Mode == aviatorv1alpha1.SelectionModeThreshold &&
+		noneWithin(rankings, policy.Spec.LatencyThreshold.Duration) {
+		fallbacks = append(fallbacks, fallback{"NoPodWithinThreshold",
+			fmt.Sprintf("no pod has P99 within %s; routing to the fastest", policy.Spec.LatencyThreshold.Duration)})
+	}
+	r.setFallbackCondition(&policy, fallbacks)
+	if policy.Spec.Topology != nil {
+		selected = latency.EnsureZoneMinimum(rankings, selected, int(policy.Spec.Topology.MinPodsPerZone))
+	}
+	if minPods := r.minActivePods(&policy, len(measured)); len(selected) < minPods {
+		before := len(selected)
+		// Prefer healthy pods, then fall back to ejected ones.
+		selected = latency.EnsureMinimum(rankings, selected, minPods)
+		selected = latency.EnsureMinimum(measured, selected, minPods)
+		guardrailNotes = append(guardrailNotes, fmt.Sprintf(
+			"minimum of %d active pods raised selection from %d to %d", minPods, before, len(selected)))
+	}
+	r.setGuardrailCondition(&policy, guardrailNotes)
+	selected = appendMissing(selected, warmUp.included)
+
+	// Exploration — briefly return excluded pods to rotation, or probe them.
+	exploring, explorationChanged := r.explore(&policy, policyKey, rankings, selected, probePorts)
 
 	// 11. Dampening — suppress flapping.
-	dampener := r.getOrCreateDampener(policyKey)
 	selectedIPs := make([]string, len(selected))
 	for i, s := range selected {
 		selectedIPs[i] = s.PodIP
 	}
 
-	if policy.Spec.Dampening != nil && policy.Spec.Dampening.Enabled {
+	switch {
+	case frozen:
+		// Leave the dampener on the applied selection, so that changes are
+		// judged against it when the freeze ends.
+	case policy.Spec.Dampening != nil && policy.Spec.Dampening.Enabled:
 		if !dampener.ShouldUpdate(
 			selectedIPs,
 			int(policy.Spec.Dampening.ThresholdPercent),
 			int(policy.Spec.Dampening.ConsecutiveIntervals),
 		) {
-			logger.V(1).Info("dampening: suppressing endpoint update", "policy", policyKey)
-			return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
+			r.setDampenedCondition(&policy, true)
+			applied := appliedSelection(dampener.Current(), append(rankings, warmUp.included...))
+			markDampened(&ev, selectedIPs, applied)
+			drainPending := r.drainers[policyKey] != nil && r.drainers[policyKey].Pending()
+			if !explorationChanged && !overridesChanged && !observing(&policy) && !drainPending {
+				logger.V(1).Info("dampening: suppressing endpoint update", "policy", policyKey)
+				policy.Status.ObservedGeneration = policy.Generation
+				ev.selected = applied
+				if err := r.writeReport(ctx, &policy, ev); err != nil {
+					logger.Error(err, "failed to write policy report")
+				}
+				if err := r.Status().Update(ctx, &policy); err != nil {
+					logger.Error(err, "failed to update policy status")
+				}
+				return ctrl.Result{RequeueAfter: r.drainRequeue(policyKey, r.getEvaluationInterval(&policy))}, nil
+			}
+			// Keep the applied selection, but apply the exploration change or
+			// let drains finish. In observe mode, this is the selection that
+			// would stay applied.
+			selected = applied
+		} else {
+			r.setDampenedCondition(&policy, false)
 		}
+	default:
+		meta.RemoveStatusCondition(&policy.Status.Conditions, "Dampened")
 	}
+	selected = appendMissing(selected, exploring)
+	selected = appendMissing(selected, overrides.pinnedSelection(ev))
+	ev.selected = selected
 
-	// 12. Build EndpointSlice pod list.
-	podEndpoints := make([]endpointslice.PodEndpoint, 0, len(selected))
+	// 12. Build EndpointSlice pod list. Terminating pods stay listed as
+	// terminating so that proxies can drain connections to them.
+	publishNotReady := service.Spec.PublishNotReadyAddresses
+	podEndpoints := make([]endpointslice.PodEndpoint, 0, len(selected)+len(terminating))
 	for _, s := range selected {
 		pod := podIPMap[s.PodIP]
+		ready, serving, _ := endpointslice.PodConditions(&pod, publishNotReady)
 		podEndpoints = append(podEndpoints, endpointslice.PodEndpoint{
 			PodName:  s.PodName,
 			PodIP:    s.PodIP,
 			NodeName: pod.Spec.NodeName,
-			Ready:    true,
+			Zone:     s.Zone,
+			Ready:    ready,
+			Serving:  serving,
+			Ports:    endpointslice.ResolvePorts(&service, &pod),
+		})
+	}
+	for i := range terminating {
+		pod := &terminating[i]
+		ready, serving, isTerminating := endpointslice.PodConditions(pod, publishNotReady)
+		podEndpoints = append(podEndpoints, endpointslice.PodEndpoint{
+			PodName:     pod.Name,
+			PodIP:       pod.Status.PodIP,
+			NodeName:    pod.Spec.NodeName,
+			Zone:        nodeZones[pod.Spec.NodeName],
+			Ready:       ready,
+			Serving:     serving,
+			Terminating: isTerminating,
+			Ports:       endpointslice.ResolvePorts(&service, pod),
 		})
 	}
 
-	// 13. Update EndpointSlice.
-	if err := r.EndpointSliceManager.Reconcile(ctx, &policy, &service, podEndpoints); err != nil {
+	// 13. Update EndpointSlice, or in observe mode, report the selection.
+	if observing(&policy) {
+		if err := r.observe(ctx, &policy, &service, selected); err != nil {
+			logger.Error(err, "failed to observe routing")
+			r.setCondition(&policy, "Ready", metav1.ConditionFalse, "ObserveFailed", err.Error())
+			_ = r.Status().Update(ctx, &policy)
+			return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
+		}
+		r.updateStatus(&policy, rankings, selected, breaker)
+		r.setCondition(&policy, "Ready", metav1.ConditionTrue, "Observing", "Selection reported in status; routing unchanged")
+		if err := r.writeReport(ctx, &policy, ev); err != nil {
+			logger.Error(err, "failed to write policy report")
+		}
+		if err := r.Status().Update(ctx, &policy); err != nil {
+			logger.Error(err, "failed to update policy status")
+		}
+		return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
+	}
+	if frozen {
+		// Leave routing as it is; evaluation and reporting carry on. Drain
+		// periods still end on time.
+		if err := r.expireFrozenDrains(ctx, &policy, policyKey, &service, podEndpoints, podIPMap); err != nil {
+			logger.Error(err, "failed to end drains during the freeze")
+		}
+		r.updateStatus(&policy, rankings, selected, breaker)
+		r.setCondition(&policy, "Ready", metav1.ConditionTrue, "Frozen",
+			"Selection reported in status; EndpointSlices left unchanged during the freeze")
+		if err := r.writeReport(ctx, &policy, ev); err != nil {
+			logger.Error(err, "failed to write policy report")
+		}
+		if err := r.Status().Update(ctx, &policy); err != nil {
+			logger.Error(err, "failed to update policy status")
+		}
+		return ctrl.Result{RequeueAfter: r.drainRequeue(policyKey, min(r.getEvaluationInterval(&policy), time.Until(frozenUntil)))}, nil
+	}
+	policy.Status.Observed = nil
+	draining := r.drain(&policy, policyKey, podEndpoints, podIPMap)
+	podEndpoints = append(podEndpoints, draining...)
+	routed, err := r.ensureRouting(ctx, &policy, &service)
+	if err != nil {
+		logger.Error(err, "failed to set up routing")
+		r.setCondition(&policy, "Ready", metav1.ConditionFalse, "RoutingFailed", err.Error())
+		_ = r.Status().Update(ctx, &policy)
+		return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
+	}
+	writes, err := r.EndpointSliceManager.Reconcile(ctx, &policy, routed, podEndpoints)
+	recordSliceWrites(&policy, writes)
+	if err != nil {
 		logger.Error(err, "failed to update EndpointSlice")
 		r.setCondition(&policy, "Ready", metav1.ConditionFalse, "EndpointSliceUpdateFailed", err.Error())
 		_ = r.Status().Update(ctx, &policy)
 		return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
 	}
 
+	if err := r.publishXDS(ctx, &policy, &service, append(rankings, warmUp.included...), selected,
+		terminating, draining, podIPMap, nodeZones, breaker); err != nil {
+		logger.Error(err, "failed to publish xDS assignments")
+	}
+
 	// 14. Update status.
+	policy.Status.RoutedService = routed.Name
 	r.updateStatus(&policy, rankings, selected, breaker)
 	r.setCondition(&policy, "Ready", metav1.ConditionTrue, "Reconciled", "Successfully updated routing")
+	if err := r.writeReport(ctx, &policy, ev); err != nil {
+		logger.Error(err, "failed to write policy report")
+	}
 	if err := r.Status().Update(ctx, &policy); err != nil {
 		logger.Error(err, "failed to update policy status")
 	}
@@ -247,7 +584,7 @@ func (r *AviatorPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		"source", r.LatencySource.Name(),
 	)
 
-	return ctrl.Result{RequeueAfter: r.getEvaluationInterval(&policy)}, nil
+	return ctrl.Result{RequeueAfter: r.drainRequeue(policyKey, r.getEvaluationInterval(&policy))}, nil
 }
 
 // handleDeletion cleans up resources when an AviatorPolicy is deleted.
@@ -257,14 +594,28 @@ func (r *AviatorPolicyReconciler) handleDeletion(ctx context.Context, policy *av
 	if controllerutil.ContainsFinalizer(policy, finalizerName) {
 		logger.Info("cleaning up resources for deleted policy", "policy", policy.Name)
 
-		if err := r.EndpointSliceManager.Cleanup(ctx, policy.Namespace, policy.Spec.TargetRef.Name); err != nil {
-			return ctrl.Result{}, fmt.Errorf("cleaning up EndpointSlice: %w", err)
+		wait, err := r.releaseRouting(ctx, policy)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("releasing routing: %w", err)
+		}
+		if wait > 0 {
+			logger.Info("waiting for the Service's own EndpointSlices before cleanup", "policy", policy.Name)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+
+		if r.XDS != nil {
+			if err := r.XDS.Remove(ctx, xdsKey(policy)); err != nil {
+				return ctrl.Result{}, fmt.Errorf("removing xDS assignments: %w", err)
+			}
 		}
 
 		// Remove per-policy state.
 		policyKey := types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}.String()
 		delete(r.breakers, policyKey)
 		delete(r.dampeners, policyKey)
+		delete(r.explorers, policyKey)
+		delete(r.smoothers, policyKey)
+		delete(r.drainers, policyKey)
 
 		controllerutil.RemoveFinalizer(policy, finalizerName)
 		if err := r.Update(ctx, policy); err != nil {
@@ -274,21 +625,470 @@ func (r *AviatorPolicyReconciler) handleDeletion(ctx context.Context, policy *av
 	return ctrl.Result{}, nil
 }
 
-// getPodsForService lists all Running pods matching the Service selector.
-func (r *AviatorPolicyReconciler) getPodsForService(ctx context.Context, service corev1.Service) ([]corev1.Pod, error) {
+// getPods lists the Running pods matching selector. It returns the pods
+// eligible for selection, which must be Ready unless publishNotReady is set,
+// and separately the terminating pods that still have an IP. A nil selector
+// matches no pods.
+func (r *AviatorPolicyReconciler) getPods(
+	ctx context.Context,
+	namespace string,
+	selector labels.Selector,
+	publishNotReady bool,
+) (ready, terminating []corev1.Pod, err error) {
+	if selector == nil {
+		return nil, nil, nil
+	}
+
 	var podList corev1.PodList
-	selector := client.MatchingLabels(service.Spec.Selector)
-	if err := r.List(ctx, &podList, selector, client.InNamespace(service.Namespace)); err != nil {
-		return nil, err
+	if err := r.List(ctx, &podList, client.MatchingLabelsSelector{Selector: selector}, client.InNamespace(namespace)); err != nil {
+		return nil, nil, err
 	}
 
-	var readyPods []corev1.Pod
 	for _, pod := range podList.Items {
-		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
-			readyPods = append(readyPods, pod)
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		if !pod.DeletionTimestamp.IsZero() {
+			if pod.Status.PodIP != "" {
+				terminating = append(terminating, pod)
+			}
+			continue
+		}
+		if publishNotReady || endpointslice.IsPodReady(&pod) {
+			ready = append(ready, pod)
+		}
+	}
+	return ready, terminating, nil
+}
+
+// smoothLatencies folds the latest snapshot into the policy's per-pod history
+// and returns smoothed stats. Without a smoothing spec, it returns latest.
+func (r *AviatorPolicyReconciler) smoothLatencies(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	key string,
+	latest map[string]latency.Stats,
+) map[string]latency.Stats {
+	spec := policy.Spec.Smoothing
+	if spec == nil {
+		delete(r.smoothers, key)
+		return latest
+	}
+
+	cfg := smoothingConfig(spec)
+	smoother, ok := r.smoothers[key]
+	if !ok {
+		smoother = latency.NewSmoother()
+		r.smoothers[key] = smoother
+	}
+	return smoother.Smooth(latest, cfg, time.Now())
+}
+
+// smoothingConfig converts a smoothing spec, defaulting the settings left at
+// zero.
+func smoothingConfig(spec *aviatorv1alpha1.SmoothingSpec) latency.SmoothingConfig {
+	cfg := latency.SmoothingConfig{
+		Method:   latency.SmoothEWMA,
+		HalfLife: spec.HalfLife.Duration,
+		Window:   spec.Window.Duration,
+		Quantile: int(spec.Quantile),
+	}
+	if spec.Method == aviatorv1alpha1.SmoothingMethodWindowQuantile {
+		cfg.Method = latency.SmoothWindowQuantile
+	}
+	if cfg.HalfLife <= 0 {
+		cfg.HalfLife = defaultSmoothingHalfLife
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultSmoothingWindow
+	}
+	if cfg.Quantile <= 0 {
+		cfg.Quantile = defaultSmoothingQuantile
+	}
+	return cfg
+}
+
+// warmUpPlan describes how pods without latency data take part in selection.
+type warmUpPlan struct {
+	// ranked holds pods with estimated stats that take part in ranking.
+	ranked []latency.PodRanking
+	// included holds pods added to the selection as-is.
+	included []latency.PodRanking
+	// status reports every unmeasured pod.
+	status []aviatorv1alpha1.UnmeasuredPodInfo
+}
+
+// planWarmUp applies the policy's warm-up mode to pods that have no entry in
+// the latency map. Pods outside their warm-up period are excluded.
+func (r *AviatorPolicyReconciler) planWarmUp(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	podIPs []string,
+	podIPMap map[string]corev1.Pod,
+	latencies map[string]latency.Stats,
+	nodeZones map[string]string,
+	measured []latency.PodRanking,
+) warmUpPlan {
+	mode := latency.WarmUpExclude
+	var window time.Duration
+	if w := policy.Spec.WarmUp; w != nil {
+		window = w.Duration.Duration
+		if window <= 0 {
+			window = defaultWarmUpDuration
+		}
+		switch w.Mode {
+		case aviatorv1alpha1.WarmUpModeInclude, "":
+			mode = latency.WarmUpInclude
+		case aviatorv1alpha1.WarmUpModeMedian:
+			mode = latency.WarmUpMedian
+		case aviatorv1alpha1.WarmUpModeSlowStart:
+			mode = latency.WarmUpSlowStart
+		}
+	}
+
+	var plan warmUpPlan
+	now := time.Now()
+	for _, ip := range podIPs {
+		if _, ok := latencies[ip]; ok {
+			continue
+		}
+		pod := podIPMap[ip]
+		since := podReadySince(pod)
+		info := aviatorv1alpha1.UnmeasuredPodInfo{
+			Name:       pod.Name,
+			PodIP:      ip,
+			State:      aviatorv1alpha1.UnmeasuredPodExcluded,
+			ReadySince: metav1.NewTime(since),
+		}
+
+		if mode != latency.WarmUpExclude && now.Sub(since) < window {
+			info.State = aviatorv1alpha1.UnmeasuredPodWarmingUp
+			rank := latency.PodRanking{
+				PodName:   pod.Name,
+				PodIP:     ip,
+				Zone:      nodeZones[pod.Spec.NodeName],
+				Estimated: true,
+			}
+			if mode == latency.WarmUpInclude {
+				plan.included = append(plan.included, rank)
+			} else {
+				progress := latency.WarmUpProgress(since, now, window)
+				rank.Stats = latency.EstimateWarmUpStats(measured, mode, progress)
+				plan.ranked = append(plan.ranked, rank)
+			}
+		}
+
+		if len(plan.status) < maxStatusPodEntries {
+			plan.status = append(plan.status, info)
+		}
+	}
+	return plan
+}
+
+// explorationConfig converts an exploration spec, defaulting the settings
+// left at zero.
+func explorationConfig(spec *aviatorv1alpha1.ExplorationSpec) latency.ExplorationConfig {
+	cfg := latency.ExplorationConfig{
+		Epsilon:  float64(spec.EpsilonPercent) / 100,
+		Interval: spec.Interval.Duration,
+		Duration: spec.Duration.Duration,
+	}
+	if cfg.Epsilon <= 0 {
+		cfg.Epsilon = defaultExplorationEpsilon
+	}
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultExplorationInterval
+	}
+	if cfg.Duration <= 0 {
+		cfg.Duration = defaultExplorationDuration
+	}
+	switch spec.Mode {
+	case aviatorv1alpha1.ExplorationModeEpsilonGreedy:
+		cfg.Mode = latency.ExploreEpsilonGreedy
+	case aviatorv1alpha1.ExplorationModeProbe:
+		cfg.Mode = latency.ExploreProbe
+	default:
+		cfg.Mode = latency.ExplorePeriodic
+	}
+	return cfg
+}
+
+// podReadySince returns when the pod last became ready, falling back to its
+// start and creation times.
+func podReadySince(pod corev1.Pod) time.Time {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return c.LastTransitionTime.Time
+		}
+	}
+	if pod.Status.StartTime != nil {
+		return pod.Status.StartTime.Time
+	}
+	return pod.CreationTimestamp.Time
+}
+
+// explore returns the excluded pods that exploration returns to rotation, and
+// whether that set changed since the last evaluation. In probe mode, excluded
+// pods are sent probe traffic in the background instead and none are returned.
+func (r *AviatorPolicyReconciler) explore(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	key string,
+	candidates []latency.PodRanking,
+	selected []latency.PodRanking,
+	probePorts map[string]int32,
+) ([]latency.PodRanking, bool) {
+	spec := policy.Spec.Exploration
+	if spec == nil {
+		delete(r.explorers, key)
+		policy.Status.ExploringPods = nil
+		return nil, false
+	}
+
+	cfg := explorationConfig(spec)
+	inSelection := make(map[string]bool, len(selected))
+	for _, s := range selected {
+		inSelection[s.PodIP] = true
+	}
+	byIP := make(map[string]latency.PodRanking, len(candidates))
+	var excluded []string
+	for _, c := range candidates {
+		if !inSelection[c.PodIP] {
+			byIP[c.PodIP] = c
+			excluded = append(excluded, c.PodIP)
+		}
+	}
+
+	explorer, ok := r.explorers[key]
+	if !ok {
+		explorer = latency.NewExplorer()
+		r.explorers[key] = explorer
+	}
+	ips, changed := explorer.Explore(excluded, cfg, time.Now())
+
+	policy.Status.ExploringPods = nil
+	exploring := make([]latency.PodRanking, 0, len(ips))
+	for _, ip := range ips {
+		exploring = append(exploring, byIP[ip])
+		policy.Status.ExploringPods = append(policy.Status.ExploringPods, byIP[ip].PodName)
+	}
+
+	if cfg.Mode == latency.ExploreProbe {
+		if len(ips) > 0 {
+			r.sendProbeTraffic(ips, probePorts)
+		}
+		return nil, false
+	}
+	return exploring, changed
+}
+
+// sendProbeTraffic probes the given pods in the background. The results are
+// discarded: the point is to generate traffic the latency source can observe.
+func (r *AviatorPolicyReconciler) sendProbeTraffic(podIPs []string, ports map[string]int32) {
+	prober := latency.NewProbeSource(ctrl.Log.WithName("exploration"), defaultTargetPort)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), explorationProbeTimeout)
+		defer cancel()
+		_, _ = prober.GetLatencies(ctx, podIPs, ports)
+	}()
+}
+
+// probePorts returns the port to probe on each pod: spec.targetPort if set,
+// otherwise the pod's first resolved TCP port of the Service. Pods with no
+// such port are left to the source's default.
+func probePorts(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+	pods []corev1.Pod,
+) map[string]int32 {
+	ports := make(map[string]int32, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.PodIP == "" {
+			continue
+		}
+		if policy.Spec.TargetPort != nil {
+			ports[pod.Status.PodIP] = *policy.Spec.TargetPort
+			continue
+		}
+		for _, p := range endpointslice.ResolvePorts(service, pod) {
+			if p.Protocol == nil || *p.Protocol == corev1.ProtocolTCP {
+				ports[pod.Status.PodIP] = *p.Port
+				break
+			}
+		}
+	}
+	return ports
+}
+
+// drainRequeue shortens the requeue delay after so that the policy is
+// evaluated again when its first drain period ends.
+func (r *AviatorPolicyReconciler) drainRequeue(key string, after time.Duration) time.Duration {
+	d := r.drainers[key]
+	if d == nil {
+		return after
+	}
+	next, ok := d.NextExpiry()
+	if !ok {
+		return after
+	}
+	return max(min(after, time.Until(next)), minDrainRequeue)
+}
+
+// expireFrozenDrains removes pods whose drain period has ended from the
+// slices of a frozen policy. The rest of the frozen endpoints stay as they
+// were last written; terminating pods are refreshed.
+func (r *AviatorPolicyReconciler) expireFrozenDrains(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	key string,
+	service *corev1.Service,
+	endpoints []endpointslice.PodEndpoint,
+	podIPMap map[string]corev1.Pod,
+) error {
+	d := r.drainers[key]
+	if d == nil || policy.Spec.Drain == nil {
+		return nil
+	}
+	if next, ok := d.NextExpiry(); !ok || time.Now().Before(next) {
+		return nil
+	}
+
+	frozen := d.Active()
+	for _, ep := range endpoints {
+		if ep.Terminating {
+			frozen = append(frozen, ep)
+		}
+	}
+	frozen = append(frozen, r.drain(policy, key, frozen, podIPMap)...)
+	routed, err := r.ensureRouting(ctx, policy, service)
+	if err != nil {
+		return err
+	}
+	writes, err := r.EndpointSliceManager.Reconcile(ctx, policy, routed, frozen)
+	recordSliceWrites(policy, writes)
+	return err
+}
+
+// drain keeps pods that left the selected set in the slice for the policy's
+// drain period, and reports them in status. It returns the endpoints to add.
+func (r *AviatorPolicyReconciler) drain(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	key string,
+	endpoints []endpointslice.PodEndpoint,
+	podIPMap map[string]corev1.Pod,
+) []endpointslice.PodEndpoint {
+	policy.Status.DrainingPods = nil
+	spec := policy.Spec.Drain
+	if spec == nil {
+		delete(r.drainers, key)
+		return nil
+	}
+	d, ok := r.drainers[key]
+	if !ok {
+		d = endpointslice.NewDrainTracker()
+		r.drainers[key] = d
+	}
+
+	exists := func(ip string) bool {
+		_, ok := podIPMap[ip]
+		return ok
+	}
+	window := spec.Duration.Duration
+	if window <= 0 {
+		window = defaultDrainDuration
+	}
+	draining := d.Update(endpoints, exists, window, time.Now())
+	added := make([]endpointslice.PodEndpoint, 0, len(draining))
+	for i, pod := range draining {
+		added = append(added, pod.Endpoint)
+		if i < maxStatusPodEntries {
+			policy.Status.DrainingPods = append(policy.Status.DrainingPods, aviatorv1alpha1.DrainingPodInfo{
+				Name:  pod.Endpoint.PodName,
+				PodIP: pod.Endpoint.PodIP,
+				Since: metav1.NewTime(pod.Since),
+				Until: metav1.NewTime(pod.Until),
+			})
+		}
+	}
+	return added
+}
+
+// recordSliceWrites adds one reconcile's EndpointSlice write counts to status.
+func recordSliceWrites(policy *aviatorv1alpha1.AviatorPolicy, writes endpointslice.WriteStats) {
+	stats := &policy.Status.EndpointSliceWrites
+	stats.Applied += int64(writes.Applied)
+	stats.Skipped += int64(writes.Skipped)
+	stats.Conflicted += int64(writes.Conflicted)
+}
+
+// appliedSelection maps the previously applied pod IPs back to rankings,
+// dropping pods that no longer exist.
+func appliedSelection(ips []string, candidates []latency.PodRanking) []latency.PodRanking {
+	byIP := make(map[string]latency.PodRanking, len(candidates))
+	for _, c := range candidates {
+		byIP[c.PodIP] = c
+	}
+	applied := make([]latency.PodRanking, 0, len(ips))
+	for _, ip := range ips {
+		if c, ok := byIP[ip]; ok {
+			applied = append(applied, c)
 		}
 	}
-	return readyPods, nil
+	return applied
+}
+
+// appendMissing appends the pods in extra that are not already in selected.
+func appendMissing(selected, extra []latency.PodRanking) []latency.PodRanking {
+	if len(extra) == 0 {
+		return selected
+	}
+	present := make(map[string]bool, len(selected))
+	for _, s := range selected {
+		present[s.PodIP] = true
+	}
+	for _, e := range extra {
+		if !present[e.PodIP] {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// minActivePods returns the selection floor from the policy guardrails.
+func (r *AviatorPolicyReconciler) minActivePods(policy *aviatorv1alpha1.AviatorPolicy, total int) int {
+	g := policy.Spec.Guardrails
+	if g == nil {
+		return 0
+	}
+	minPods := int(g.MinActivePods)
+	if byPercent := (total*int(g.MinActivePercent) + 99) / 100; byPercent > minPods {
+		minPods = byPercent
+	}
+	if minPods > total {
+		minPods = total
+	}
+	return minPods
+}
+
+// getNodeZones returns the topology zone of each node hosting one of the pods.
+// Nodes that cannot be read or carry no zone label are omitted.
+func (r *AviatorPolicyReconciler) getNodeZones(ctx context.Context, pods []corev1.Pod) map[string]string {
+	zones := make(map[string]string)
+	for _, pod := range pods {
+		nodeName := pod.Spec.NodeName
+		if nodeName == "" {
+			continue
+		}
+		if _, seen := zones[nodeName]; seen {
+			continue
+		}
+		var node corev1.Node
+		if err := r.Get(ctx, types.NamespacedName{Name: nodeName}, &node); err != nil {
+			log.FromContext(ctx).V(1).Info("unable to read node zone", "node", nodeName, "error", err)
+			zones[nodeName] = ""
+			continue
+		}
+		zones[nodeName] = node.Labels[corev1.LabelTopologyZone]
+	}
+	return zones
 }
 
 // selectPods applies the configured selection strategy.
@@ -315,6 +1115,20 @@ func (r *AviatorPolicyReconciler) selectPods(policy *aviatorv1alpha1.AviatorPoli
 	case aviatorv1alpha1.SelectionModeThreshold:
 		return latency.SelectByThreshold(ranked, policy.Spec.LatencyThreshold.Duration)
 
+	case aviatorv1alpha1.SelectionModeOutlier:
+		return r.selectNonOutliers(policy.Spec.Selection.Outlier, ranked)
+
+	case aviatorv1alpha1.SelectionModeScore:
+		// Pods are already ordered by composite score.
+		if policy.Spec.Selection.TopN != nil {
+			return latency.SelectTopN(ranked, int(*policy.Spec.Selection.TopN))
+		}
+		pct := defaultPercentage
+		if policy.Spec.Selection.Percentage != nil {
+			pct = *policy.Spec.Selection.Percentage
+		}
+		return latency.SelectTopPercent(ranked, int(pct))
+
 	default:
 		// Default to percentage mode.
 		pct := defaultPercentage
@@ -325,6 +1139,71 @@ func (r *AviatorPolicyReconciler) selectPods(policy *aviatorv1alpha1.AviatorPoli
 	}
 }
 
+// scorePods gathers resource signals for each ranked pod and orders the pods
+// by composite score. If the metrics API is unavailable, CPU and memory
+// signals are left at zero and scoring proceeds on the remaining signals.
+func (r *AviatorPolicyReconciler) scorePods(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	podIPMap map[string]corev1.Pod,
+	rankings []latency.PodRanking,
+) []latency.PodRanking {
+	var weights latency.Weights
+	if w := policy.Spec.Selection.Weights; w != nil {
+		weights = latency.Weights{
+			P50:       float64(w.P50),
+			P99:       float64(w.P99),
+			ErrorRate: float64(w.ErrorRate),
+			CPU:       float64(w.CPU),
+			Memory:    float64(w.Memory),
+			Restarts:  float64(w.Restarts),
+		}
+	}
+
+	var usage map[string]podmetrics.Utilization
+	if weights.CPU > 0 || weights.Memory > 0 {
+		pods := make([]corev1.Pod, 0, len(rankings))
+		for _, rank := range rankings {
+			pods = append(pods, podIPMap[rank.PodIP])
+		}
+		var err error
+		usage, err = podmetrics.GetUtilization(ctx, r.Client, policy.Namespace, pods)
+		if err != nil {
+			log.FromContext(ctx).Error(err, "failed to read pod metrics, scoring without CPU and memory")
+		}
+	}
+
+	for i := range rankings {
+		pod := podIPMap[rankings[i].PodIP]
+		u := usage[pod.Name]
+		rankings[i].Resources = latency.ResourceSignals{
+			CPUUtilization:    u.CPU,
+			MemoryUtilization: u.Memory,
+			Restarts:          podmetrics.RestartCount(pod),
+		}
+	}
+	return latency.ScorePods(rankings, weights)
+}
+
+// selectNonOutliers applies outlier detection with defaults filled in.
+func (r *AviatorPolicyReconciler) selectNonOutliers(spec *aviatorv1alpha1.OutlierDetection, ranked []latency.PodRanking) []latency.PodRanking {
+	method := latency.OutlierMedianRatio
+	factor := defaultOutlierFactor
+	minSamples := defaultOutlierMinSamples
+	if spec != nil {
+		if spec.Method == aviatorv1alpha1.OutlierMethodMAD {
+			method = latency.OutlierMAD
+		}
+		if spec.Factor != nil && spec.Factor.Sign() > 0 {
+			factor = spec.Factor.AsApproximateFloat64()
+		}
+		if spec.MinSamples != nil {
+			minSamples = *spec.MinSamples
+		}
+	}
+	return latency.SelectNonOutliers(ranked, method, factor, int64(minSamples))
+}
+
 func (r *AviatorPolicyReconciler) getEvaluationInterval(policy *aviatorv1alpha1.AviatorPolicy) time.Duration {
 	if policy.Spec.EvaluationInterval.Duration > 0 {
 		return policy.Spec.EvaluationInterval.Duration
@@ -337,18 +1216,37 @@ func (r *AviatorPolicyReconciler) getOrCreateBreaker(policy *aviatorv1alpha1.Avi
 		return nil
 	}
 
+	cfg := circuitbreaker.Config{
+		P99Threshold:          policy.Spec.CircuitBreaker.P99Threshold.Duration,
+		ErrorRateThreshold:    float64(policy.Spec.CircuitBreaker.ErrorRateThreshold) / 100,
+		ConsecutiveViolations: policy.Spec.CircuitBreaker.ConsecutiveViolations,
+		ConsecutiveFailures:   int64(policy.Spec.CircuitBreaker.ConsecutiveFailures),
+		EjectUnreachable:      policy.Spec.CircuitBreaker.EjectUnreachable,
+		RecoveryInterval:      policy.Spec.CircuitBreaker.RecoveryInterval.Duration,
+	}
 	b, ok := r.breakers[key]
 	if !ok {
-		b = circuitbreaker.New(circuitbreaker.Config{
-			P99Threshold:          policy.Spec.CircuitBreaker.P99Threshold.Duration,
-			ConsecutiveViolations: policy.Spec.CircuitBreaker.ConsecutiveViolations,
-			RecoveryInterval:      policy.Spec.CircuitBreaker.RecoveryInterval.Duration,
-		})
+		b = circuitbreaker.New(cfg)
 		r.breakers[key] = b
+	} else {
+		// Schedules and spec edits may change the thresholds; pod state
+		// carries over.
+		b.Configure(cfg)
 	}
 	return b
 }
 
+// breakerObservation is what the circuit breaker judges a pod on.
+func breakerObservation(stats latency.Stats) circuitbreaker.Observation {
+	return circuitbreaker.Observation{
+		P99:              stats.P99,
+		ErrorRate:        stats.ErrorRate,
+		TrailingFailures: stats.TrailingFailures,
+		AllFailed:        stats.AllFailed,
+		Unreachable:      stats.Unreachable,
+	}
+}
+
 func (r *AviatorPolicyReconciler) getOrCreateDampener(key string) *latency.DampeningState {
 	d, ok := r.dampeners[key]
 	if !ok {
@@ -364,6 +1262,7 @@ func (r *AviatorPolicyReconciler) updateStatus(
 	selected []latency.PodRanking,
 	breaker *circuitbreaker.Breaker,
 ) {
+	policy.Status.ObservedGeneration = policy.Generation
 	policy.Status.LastEvaluationTime = metav1.Now()
 	policy.Status.ActivePods = int32(len(selected))
 	policy.Status.TotalPods = int32(len(allRankings))
@@ -382,11 +1281,23 @@ func (r *AviatorPolicyReconciler) updateStatus(
 		info := aviatorv1alpha1.PodLatencyInfo{
 			Name:  r.PodName,
 			PodIP: r.PodIP,
+			Zone:  r.Zone,
 			P50:   metav1.Duration{Duration: r.Stats.P50},
 			P99:   metav1.Duration{Duration: r.Stats.P99},
 		}
 		if breaker != nil {
-			info.CircuitBroken = breaker.IsEjected(r.PodIP)
+			if ps, ok := breaker.GetState(r.PodIP); ok {
+				info.CircuitBroken = ps.State == circuitbreaker.StateOpen
+				info.CircuitBreakerCause = string(ps.Cause)
+			}
+		}
+		if r.ScoreBreakdown != nil {
+			score := int32(r.Score * scoreScale)
+			info.Score = &score
+			info.ScoreBreakdown = make(map[string]int32, len(r.ScoreBreakdown))
+			for signal, contribution := range r.ScoreBreakdown {
+				info.ScoreBreakdown[string(signal)] = int32(contribution * scoreScale)
+			}
 		}
 		podInfos = append(podInfos, info)
 	}
@@ -410,10 +1321,36 @@ func (r *AviatorPolicyReconciler) setCondition(policy *aviatorv1alpha1.AviatorPo
 	})
 }
 
+// eventf emits an Event on the policy, if a recorder is set.
+func (r *AviatorPolicyReconciler) eventf(policy *aviatorv1alpha1.AviatorPolicy, eventType, reason, format string, args ...interface{}) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(policy, eventType, reason, format, args...)
+}
+
+// setGuardrailCondition reports whether any guardrail overrode the selection.
+func (r *AviatorPolicyReconciler) setGuardrailCondition(policy *aviatorv1alpha1.AviatorPolicy, notes []string) {
+	if policy.Spec.Guardrails == nil {
+		meta.RemoveStatusCondition(&policy.Status.Conditions, "GuardrailActive")
+		return
+	}
+	if len(notes) == 0 {
+		r.setCondition(policy, "GuardrailActive", metav1.ConditionFalse, "WithinLimits", "Selection is within guardrail limits")
+		return
+	}
+	r.setCondition(policy, "GuardrailActive", metav1.ConditionTrue, "SelectionOverridden", strings.Join(notes, "; "))
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *AviatorPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("aviator-controller")
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&aviatorv1alpha1.AviatorPolicy{}).
 		Owns(&discoveryv1.EndpointSlice{}).
+		Owns(&corev1.Service{}).
+		Watches(&aviatorv1alpha1.ClusterAviatorPolicy{}, handler.EnqueueRequestsFromMapFunc(r.policiesForClusterPolicy)).
 		Complete(r)
 }
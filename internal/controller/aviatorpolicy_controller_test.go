@@ -40,7 +40,7 @@ type mockLatencySource struct {
 	err       error
 }
 
-func (m *mockLatencySource) GetLatencies(_ context.Context, podIPs []string) (map[string]latency.Stats, error) {
+func (m *mockLatencySource) GetLatencies(_ context.Context, podIPs []string, _ map[string]int32) (map[string]latency.Stats, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
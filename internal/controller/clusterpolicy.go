@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// matchClusterPolicies returns the ClusterAviatorPolicies whose namespace
+// selector matches the namespace, sorted by name. Cluster policies with an
+// invalid selector match nothing.
+func (r *AviatorPolicyReconciler) matchClusterPolicies(ctx context.Context, namespace string) ([]aviatorv1alpha1.ClusterAviatorPolicy, error) {
+	var list aviatorv1alpha1.ClusterAviatorPolicyList
+	if err := r.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+
+	var ns corev1.Namespace
+	if err := r.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	nsLabels := labels.Set(ns.Labels)
+
+	var matched []aviatorv1alpha1.ClusterAviatorPolicy
+	for _, c := range list.Items {
+		if c.Spec.NamespaceSelector != nil {
+			sel, err := metav1.LabelSelectorAsSelector(c.Spec.NamespaceSelector)
+			if err != nil || !sel.Matches(nsLabels) {
+				continue
+			}
+		}
+		matched = append(matched, c)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Name < matched[j].Name })
+	return matched, nil
+}
+
+// applyClusterDefaults fills the settings the policy leaves unset from the
+// cluster policies' defaults. The first cluster policy that sets a field
+// wins. Only the in-memory spec changes.
+func applyClusterDefaults(policy *aviatorv1alpha1.AviatorPolicy, clusters []aviatorv1alpha1.ClusterAviatorPolicy) {
+	spec := &policy.Spec
+	for _, c := range clusters {
+		d := c.Spec.Defaults
+		if d == nil {
+			continue
+		}
+		if spec.CircuitBreaker == nil && d.CircuitBreaker != nil {
+			spec.CircuitBreaker = d.CircuitBreaker.DeepCopy()
+		}
+		if spec.Dampening == nil && d.Dampening != nil {
+			spec.Dampening = d.Dampening.DeepCopy()
+		}
+		if spec.Topology == nil && d.Topology != nil {
+			spec.Topology = d.Topology.DeepCopy()
+		}
+		if spec.Guardrails == nil && d.Guardrails != nil {
+			spec.Guardrails = d.Guardrails.DeepCopy()
+		}
+		if spec.Drain == nil && d.Drain != nil {
+			spec.Drain = d.Drain.DeepCopy()
+		}
+		if spec.WarmUp == nil && d.WarmUp != nil {
+			spec.WarmUp = d.WarmUp.DeepCopy()
+		}
+		if spec.Smoothing == nil && d.Smoothing != nil {
+			spec.Smoothing = d.Smoothing.DeepCopy()
+		}
+		if spec.Exploration == nil && d.Exploration != nil {
+			spec.Exploration = d.Exploration.DeepCopy()
+		}
+	}
+}
+
+// applyClusterLimits clamps the policy's settings to the cluster policies'
+// limits, so the strictest limit applies, and describes each change. It runs
+// after schedules so overrides are held to the limits too.
+func (r *AviatorPolicyReconciler) applyClusterLimits(policy *aviatorv1alpha1.AviatorPolicy, clusters []aviatorv1alpha1.ClusterAviatorPolicy) []string {
+	spec := &policy.Spec
+	guardrails := func() *aviatorv1alpha1.GuardrailsSpec {
+		if spec.Guardrails == nil {
+			spec.Guardrails = &aviatorv1alpha1.GuardrailsSpec{}
+		}
+		return spec.Guardrails
+	}
+
+	var notes []string
+	for _, c := range clusters {
+		l := c.Spec.Limits
+		if l == nil {
+			continue
+		}
+		if l.MinEvaluationInterval != nil && r.getEvaluationInterval(policy) < l.MinEvaluationInterval.Duration {
+			spec.EvaluationInterval = *l.MinEvaluationInterval
+			notes = append(notes, fmt.Sprintf("evaluationInterval raised to %s by %s", l.MinEvaluationInterval.Duration, c.Name))
+		}
+		if l.MinActivePods != nil && (spec.Guardrails == nil || spec.Guardrails.MinActivePods < *l.MinActivePods) {
+			guardrails().MinActivePods = *l.MinActivePods
+			notes = append(notes, fmt.Sprintf("guardrails.minActivePods raised to %d by %s", *l.MinActivePods, c.Name))
+		}
+		if l.MinActivePercent != nil && (spec.Guardrails == nil || spec.Guardrails.MinActivePercent < *l.MinActivePercent) {
+			guardrails().MinActivePercent = *l.MinActivePercent
+			notes = append(notes, fmt.Sprintf("guardrails.minActivePercent raised to %d by %s", *l.MinActivePercent, c.Name))
+		}
+		if l.MaxEjectionPercent != nil {
+			g := guardrails()
+			if g.MaxEjectionPercent == nil || *g.MaxEjectionPercent > *l.MaxEjectionPercent {
+				limit := *l.MaxEjectionPercent
+				g.MaxEjectionPercent = &limit
+				notes = append(notes, fmt.Sprintf("guardrails.maxEjectionPercent lowered to %d by %s", limit, c.Name))
+			}
+		}
+		if l.ObserveOnly && spec.Mode != aviatorv1alpha1.PolicyModeObserve {
+			spec.Mode = aviatorv1alpha1.PolicyModeObserve
+			notes = append(notes, fmt.Sprintf("mode set to observe by %s", c.Name))
+		}
+	}
+	return notes
+}
+
+// recordClusterPolicies reports the matching cluster policies and the
+// limits they enforced in status and the ClusterPolicyApplied condition, and
+// emits an Event when the set of cluster policies changes.
+func (r *AviatorPolicyReconciler) recordClusterPolicies(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	clusters []aviatorv1alpha1.ClusterAviatorPolicy,
+	notes []string,
+) {
+	var names []string
+	for _, c := range clusters {
+		names = append(names, c.Name)
+	}
+	if !slices.Equal(names, policy.Status.ClusterPolicies) {
+		r.eventf(policy, corev1.EventTypeNormal, "ClusterPoliciesChanged", "Inheriting from cluster policies: %s",
+			formatNames(names))
+	}
+	policy.Status.ClusterPolicies = names
+
+	switch {
+	case len(names) == 0:
+		meta.RemoveStatusCondition(&policy.Status.Conditions, "ClusterPolicyApplied")
+	case len(notes) > 0:
+		r.setCondition(policy, "ClusterPolicyApplied", metav1.ConditionTrue, "LimitsEnforced", strings.Join(notes, "; "))
+	default:
+		r.setCondition(policy, "ClusterPolicyApplied", metav1.ConditionTrue, "WithinLimits",
+			fmt.Sprintf("Inheriting from %s", formatNames(names)))
+	}
+}
+
+// policiesForClusterPolicy requeues every AviatorPolicy when a cluster
+// policy changes, since a selector edit can both add and drop namespaces.
+func (r *AviatorPolicyReconciler) policiesForClusterPolicy(ctx context.Context, _ client.Object) []reconcile.Request {
+	var list aviatorv1alpha1.AviatorPolicyList
+	if err := r.List(ctx, &list); err != nil {
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, p := range list.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&p)})
+	}
+	return requests
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func clusterPolicy(name string, selector map[string]string, spec aviatorv1alpha1.ClusterAviatorPolicySpec) *aviatorv1alpha1.ClusterAviatorPolicy {
+	if selector != nil {
+		spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+	return &aviatorv1alpha1.ClusterAviatorPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: spec}
+}
+
+func TestMatchClusterPolicies(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = aviatorv1alpha1.AddToScheme(scheme)
+	objs := []client.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Labels: map[string]string{"tier": "prod"}}},
+		clusterPolicy("prod", map[string]string{"tier": "prod"}, aviatorv1alpha1.ClusterAviatorPolicySpec{}),
+		clusterPolicy("dev", map[string]string{"tier": "dev"}, aviatorv1alpha1.ClusterAviatorPolicySpec{}),
+		clusterPolicy("baseline", nil, aviatorv1alpha1.ClusterAviatorPolicySpec{}),
+	}
+	r := &AviatorPolicyReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()}
+
+	matched, err := r.matchClusterPolicies(context.Background(), "shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 2 || matched[0].Name != "baseline" || matched[1].Name != "prod" {
+		t.Errorf("matched %d cluster policies, want [baseline prod]: %+v", len(matched), matched)
+	}
+
+	// A namespace that is gone has no labels, so only empty selectors match.
+	matched, err = r.matchClusterPolicies(context.Background(), "missing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matched) != 1 || matched[0].Name != "baseline" {
+		t.Errorf("matched %+v in a missing namespace, want [baseline]", matched)
+	}
+}
+
+func TestApplyClusterDefaults(t *testing.T) {
+	ownDampening := &aviatorv1alpha1.DampeningSpec{Enabled: false}
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{Dampening: ownDampening}}
+	clusters := []aviatorv1alpha1.ClusterAviatorPolicy{
+		*clusterPolicy("a", nil, aviatorv1alpha1.ClusterAviatorPolicySpec{Defaults: &aviatorv1alpha1.PolicyDefaults{
+			CircuitBreaker: &aviatorv1alpha1.CircuitBreakerSpec{Enabled: true, ConsecutiveViolations: 2},
+			Dampening:      &aviatorv1alpha1.DampeningSpec{Enabled: true},
+		}}),
+		*clusterPolicy("b", nil, aviatorv1alpha1.ClusterAviatorPolicySpec{Defaults: &aviatorv1alpha1.PolicyDefaults{
+			CircuitBreaker: &aviatorv1alpha1.CircuitBreakerSpec{Enabled: true, ConsecutiveViolations: 5},
+			Drain:          &aviatorv1alpha1.DrainSpec{Duration: metav1.Duration{Duration: time.Minute}},
+		}}),
+	}
+
+	applyClusterDefaults(policy, clusters)
+	if cb := policy.Spec.CircuitBreaker; cb == nil || cb.ConsecutiveViolations != 2 {
+		t.Errorf("circuitBreaker = %+v, want the first cluster policy's", cb)
+	}
+	if policy.Spec.Dampening != ownDampening {
+		t.Error("the policy's own dampening was replaced")
+	}
+	if d := policy.Spec.Drain; d == nil || d.Duration.Duration != time.Minute {
+		t.Errorf("drain = %+v, want the second cluster policy's", d)
+	}
+	policy.Spec.CircuitBreaker.ConsecutiveViolations = 9
+	if clusters[0].Spec.Defaults.CircuitBreaker.ConsecutiveViolations != 2 {
+		t.Error("defaults were shared with the policy instead of copied")
+	}
+}
+
+func TestApplyClusterLimits(t *testing.T) {
+	r := &AviatorPolicyReconciler{}
+	int32Ptr := func(v int32) *int32 { return &v }
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		Mode:               aviatorv1alpha1.PolicyModeEnforce,
+		EvaluationInterval: metav1.Duration{Duration: time.Second},
+		Guardrails:         &aviatorv1alpha1.GuardrailsSpec{MinActivePods: 4, MaxEjectionPercent: int32Ptr(50)},
+	}}
+	clusters := []aviatorv1alpha1.ClusterAviatorPolicy{
+		*clusterPolicy("a", nil, aviatorv1alpha1.ClusterAviatorPolicySpec{Limits: &aviatorv1alpha1.PolicyLimits{
+			MinEvaluationInterval: &metav1.Duration{Duration: 5 * time.Second},
+			MinActivePods:         int32Ptr(2),
+			MaxEjectionPercent:    int32Ptr(30),
+		}}),
+		*clusterPolicy("b", nil, aviatorv1alpha1.ClusterAviatorPolicySpec{Limits: &aviatorv1alpha1.PolicyLimits{
+			MinEvaluationInterval: &metav1.Duration{Duration: 3 * time.Second},
+			MinActivePercent:      int32Ptr(25),
+			MaxEjectionPercent:    int32Ptr(20),
+			ObserveOnly:           true,
+		}}),
+	}
+
+	notes := r.applyClusterLimits(policy, clusters)
+	spec := policy.Spec
+	if spec.EvaluationInterval.Duration != 5*time.Second {
+		t.Errorf("evaluationInterval = %s, want the stricter 5s", spec.EvaluationInterval.Duration)
+	}
+	if spec.Guardrails.MinActivePods != 4 || spec.Guardrails.MinActivePercent != 25 {
+		t.Errorf("guardrails = %+v, want minActivePods kept at 4 and minActivePercent raised to 25", spec.Guardrails)
+	}
+	if *spec.Guardrails.MaxEjectionPercent != 20 {
+		t.Errorf("maxEjectionPercent = %d, want the stricter 20", *spec.Guardrails.MaxEjectionPercent)
+	}
+	if spec.Mode != aviatorv1alpha1.PolicyModeObserve {
+		t.Errorf("mode = %q, want observe", spec.Mode)
+	}
+	if len(notes) != 5 {
+		t.Errorf("got %d notes, want 5: %v", len(notes), notes)
+	}
+
+	// A policy without guardrails gets the cap.
+	bare := &aviatorv1alpha1.AviatorPolicy{}
+	r.applyClusterLimits(bare, clusters[:1])
+	if g := bare.Spec.Guardrails; g == nil || g.MaxEjectionPercent == nil || *g.MaxEjectionPercent != 30 || g.MinActivePods != 2 {
+		t.Errorf("guardrails = %+v, want minActivePods 2 and maxEjectionPercent 30", g)
+	}
+}
+
+func TestRecordClusterPolicies(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{Recorder: recorder}
+	policy := &aviatorv1alpha1.AviatorPolicy{}
+	clusters := []aviatorv1alpha1.ClusterAviatorPolicy{*clusterPolicy("baseline", nil, aviatorv1alpha1.ClusterAviatorPolicySpec{})}
+
+	r.recordClusterPolicies(policy, clusters, nil)
+	cond := meta.FindStatusCondition(policy.Status.Conditions, "ClusterPolicyApplied")
+	if cond == nil || cond.Reason != "WithinLimits" {
+		t.Fatalf("condition = %+v, want WithinLimits", cond)
+	}
+	if len(policy.Status.ClusterPolicies) != 1 || len(recorder.Events) != 1 {
+		t.Errorf("clusterPolicies = %v with %d events, want [baseline] and 1 event", policy.Status.ClusterPolicies, len(recorder.Events))
+	}
+	<-recorder.Events
+
+	r.recordClusterPolicies(policy, clusters, []string{"mode set to observe by baseline"})
+	if cond := meta.FindStatusCondition(policy.Status.Conditions, "ClusterPolicyApplied"); cond.Reason != "LimitsEnforced" {
+		t.Errorf("reason = %q, want LimitsEnforced", cond.Reason)
+	}
+	if len(recorder.Events) != 0 {
+		t.Error("an unchanged set of cluster policies emitted an Event")
+	}
+
+	r.recordClusterPolicies(policy, nil, nil)
+	if meta.FindStatusCondition(policy.Status.Conditions, "ClusterPolicyApplied") != nil || policy.Status.ClusterPolicies != nil {
+		t.Error("condition and list should be cleared when no cluster policy matches")
+	}
+}
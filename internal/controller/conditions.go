@@ -0,0 +1,134 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fallback records a selection step that could not apply its rule and fell
+// back to routing to more pods than the rule allows.
+type fallback struct {
+	reason  string
+	message string
+}
+
+// noneWithin reports whether no ranked pod has a P99 within threshold, in
+// which case SelectByThreshold falls back to the fastest pod.
+func noneWithin(ranked []latency.PodRanking, threshold time.Duration) bool {
+	for _, p := range ranked {
+		if p.Stats.P99 <= threshold {
+			return false
+		}
+	}
+	return len(ranked) > 0
+}
+
+// setFallbackCondition reports whether selection fell back this evaluation.
+// An Event is emitted when it starts to.
+func (r *AviatorPolicyReconciler) setFallbackCondition(policy *aviatorv1alpha1.AviatorPolicy, fallbacks []fallback) {
+	if len(fallbacks) == 0 {
+		r.setCondition(policy, "FallbackSelection", metav1.ConditionFalse, "SelectionApplied",
+			"Selection applied without fallback")
+		return
+	}
+	messages := make([]string, len(fallbacks))
+	for i, f := range fallbacks {
+		messages[i] = f.message
+	}
+	message := strings.Join(messages, "; ")
+	if !meta.IsStatusConditionTrue(policy.Status.Conditions, "FallbackSelection") {
+		r.eventf(policy, corev1.EventTypeWarning, "FallbackSelection", "Selection fell back: %s", message)
+	}
+	r.setCondition(policy, "FallbackSelection", metav1.ConditionTrue, fallbacks[0].reason, message)
+}
+
+// setDegradedCondition reports whether the circuit breaker has pods ejected.
+func (r *AviatorPolicyReconciler) setDegradedCondition(policy *aviatorv1alpha1.AviatorPolicy, ejected, total int) {
+	if ejected == 0 {
+		r.setCondition(policy, "Degraded", metav1.ConditionFalse, "NoPodsEjected", "No pods are ejected")
+		return
+	}
+	r.setCondition(policy, "Degraded", metav1.ConditionTrue, "PodsEjected",
+		fmt.Sprintf("%d of %d pods are ejected by the circuit breaker", ejected, total))
+}
+
+// setDampenedCondition reports whether dampening held back the latest
+// selection. An Event is emitted when it starts to.
+func (r *AviatorPolicyReconciler) setDampenedCondition(policy *aviatorv1alpha1.AviatorPolicy, suppressed bool) {
+	if !suppressed {
+		r.setCondition(policy, "Dampened", metav1.ConditionFalse, "UpdateApplied", "Selection changes are applied")
+		return
+	}
+	if !meta.IsStatusConditionTrue(policy.Status.Conditions, "Dampened") {
+		r.eventf(policy, corev1.EventTypeNormal, "UpdateSuppressed",
+			"Dampening is holding back a selection change until it persists")
+	}
+	r.setCondition(policy, "Dampened", metav1.ConditionTrue, "UpdateSuppressed",
+		"Selection change held back until it persists")
+}
+
+// recordBreakerTransition emits Events on the policy and the pod when the
+// circuit breaker ejects or re-admits a pod, naming the condition behind it.
+func (r *AviatorPolicyReconciler) recordBreakerTransition(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	pod corev1.Pod,
+	rank latency.PodRanking,
+	before, after circuitbreaker.PodState,
+) {
+	if r.Recorder == nil || before.State == after.State {
+		return
+	}
+	switch {
+	case after.State == circuitbreaker.StateOpen && before.State == circuitbreaker.StateClosed:
+		cause := breakerCause(after, rank.Stats)
+		r.eventf(policy, corev1.EventTypeWarning, "PodEjected",
+			"Circuit breaker ejected pod %s (%s)", rank.PodName, cause)
+		r.Recorder.Eventf(&pod, corev1.EventTypeWarning, "PodEjected",
+			"Ejected by AviatorPolicy %s (%s)", policy.Name, cause)
+	case after.State == circuitbreaker.StateClosed:
+		r.eventf(policy, corev1.EventTypeNormal, "PodRecovered",
+			"Circuit breaker re-admitted pod %s (P99 %s)", rank.PodName, rank.Stats.P99)
+		r.Recorder.Eventf(&pod, corev1.EventTypeNormal, "PodRecovered",
+			"Re-admitted by AviatorPolicy %s (P99 %s)", policy.Name, rank.Stats.P99)
+	}
+}
+
+// breakerCause describes the condition that made the circuit breaker eject
+// a pod.
+func breakerCause(state circuitbreaker.PodState, stats latency.Stats) string {
+	switch state.Cause {
+	case circuitbreaker.CauseErrorRate:
+		return fmt.Sprintf("error rate %.0f%%", stats.ErrorRate*100)
+	case circuitbreaker.CauseConsecutiveFailures:
+		return fmt.Sprintf("%d consecutive probe failures", state.ConsecutiveFailures)
+	case circuitbreaker.CauseUnreachable:
+		return "unreachable"
+	default:
+		return fmt.Sprintf("P99 %s", stats.P99)
+	}
+}
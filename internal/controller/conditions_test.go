@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestNoneWithin(t *testing.T) {
+	ranked := []latency.PodRanking{
+		{PodIP: "a", Stats: latency.Stats{P99: 150 * time.Millisecond}},
+		{PodIP: "b", Stats: latency.Stats{P99: 200 * time.Millisecond}},
+	}
+	if !noneWithin(ranked, 100*time.Millisecond) {
+		t.Error("expected no pod within 100ms")
+	}
+	if noneWithin(ranked, 150*time.Millisecond) {
+		t.Error("pod a is within 150ms")
+	}
+	if noneWithin(nil, time.Millisecond) {
+		t.Error("an empty ranking has nothing to fall back from")
+	}
+}
+
+func TestConditionEventsOnTransitionOnly(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{Recorder: recorder}
+	policy := &aviatorv1alpha1.AviatorPolicy{ObjectMeta: metav1.ObjectMeta{Name: "p", Generation: 2}}
+
+	fb := []fallback{{"AllPodsEjected", "all pods ejected"}}
+	r.setFallbackCondition(policy, fb)
+	r.setFallbackCondition(policy, fb)
+	r.setDampenedCondition(policy, true)
+	r.setDampenedCondition(policy, true)
+
+	if got := len(recorder.Events); got != 2 {
+		t.Errorf("got %d events, want one per transition (2)", got)
+	}
+	cond := meta.FindStatusCondition(policy.Status.Conditions, "FallbackSelection")
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "AllPodsEjected" || cond.ObservedGeneration != 2 {
+		t.Errorf("FallbackSelection condition = %+v", cond)
+	}
+
+	r.setFallbackCondition(policy, nil)
+	if !meta.IsStatusConditionFalse(policy.Status.Conditions, "FallbackSelection") {
+		t.Error("FallbackSelection should be False without fallbacks")
+	}
+}
+
+func TestRecordBreakerTransition(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{Recorder: recorder}
+	policy := &aviatorv1alpha1.AviatorPolicy{ObjectMeta: metav1.ObjectMeta{Name: "p"}}
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}
+	rank := latency.PodRanking{PodName: "web-1", Stats: latency.Stats{P99: time.Second}}
+
+	closed := circuitbreaker.PodState{State: circuitbreaker.StateClosed}
+	r.recordBreakerTransition(policy, pod, rank, closed, closed)
+	if len(recorder.Events) != 0 {
+		t.Fatalf("no transition should emit nothing, got %d events", len(recorder.Events))
+	}
+	open := circuitbreaker.PodState{State: circuitbreaker.StateOpen, Cause: circuitbreaker.CauseLatency}
+	r.recordBreakerTransition(policy, pod, rank, closed, open)
+	r.recordBreakerTransition(policy, pod, rank, circuitbreaker.PodState{State: circuitbreaker.StateHalfOpen}, closed)
+	// One Event on the policy and one on the pod for each transition.
+	if got := len(recorder.Events); got != 4 {
+		t.Fatalf("got %d events, want 4", got)
+	}
+	if e := <-recorder.Events; !strings.Contains(e, "P99 1s") {
+		t.Errorf("event %q should name the P99", e)
+	}
+	for len(recorder.Events) > 0 {
+		<-recorder.Events
+	}
+
+	rank.Stats.ErrorRate = 0.5
+	r.recordBreakerTransition(policy, pod, rank, closed,
+		circuitbreaker.PodState{State: circuitbreaker.StateOpen, Cause: circuitbreaker.CauseErrorRate})
+	if e := <-recorder.Events; !strings.Contains(e, "error rate 50%") {
+		t.Errorf("event %q should name the error rate", e)
+	}
+}
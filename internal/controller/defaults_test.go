@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/endpointslice"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWarmUpDefaultDuration(t *testing.T) {
+	r := &AviatorPolicyReconciler{}
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		WarmUp: &aviatorv1alpha1.WarmUpSpec{},
+	}}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1"},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type: corev1.PodReady, Status: corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-10 * time.Second)),
+		}}},
+	}
+
+	plan := r.planWarmUp(policy, []string{"10.0.0.1"}, map[string]corev1.Pod{"10.0.0.1": pod}, nil, nil, nil)
+	if len(plan.included) != 1 || plan.status[0].State != aviatorv1alpha1.UnmeasuredPodWarmingUp {
+		t.Errorf("a pod ready for 10s should be warming up with the default 60s duration, got %+v", plan.status)
+	}
+}
+
+func TestExplorationConfigDefaults(t *testing.T) {
+	cfg := explorationConfig(&aviatorv1alpha1.ExplorationSpec{})
+	if cfg.Interval != 60*time.Second || cfg.Duration != 10*time.Second || cfg.Epsilon != 0.1 {
+		t.Errorf("got interval %s, duration %s, epsilon %v; want 60s, 10s, 0.1", cfg.Interval, cfg.Duration, cfg.Epsilon)
+	}
+	if cfg.Mode != latency.ExplorePeriodic {
+		t.Errorf("mode = %v, want periodic", cfg.Mode)
+	}
+
+	cfg = explorationConfig(&aviatorv1alpha1.ExplorationSpec{
+		Mode:     aviatorv1alpha1.ExplorationModeProbe,
+		Interval: metav1.Duration{Duration: 5 * time.Minute},
+		Duration: metav1.Duration{Duration: time.Second},
+	})
+	if cfg.Interval != 5*time.Minute || cfg.Duration != time.Second || cfg.Mode != latency.ExploreProbe {
+		t.Errorf("set values were not kept: %+v", cfg)
+	}
+}
+
+func TestSmoothingConfigDefaults(t *testing.T) {
+	cfg := smoothingConfig(&aviatorv1alpha1.SmoothingSpec{})
+	if cfg.Method != latency.SmoothEWMA || cfg.HalfLife != 30*time.Second {
+		t.Errorf("got method %v, half-life %s; want ewma, 30s", cfg.Method, cfg.HalfLife)
+	}
+	if cfg.Window != 60*time.Second || cfg.Quantile != 50 {
+		t.Errorf("got window %s, quantile %d; want 60s, 50", cfg.Window, cfg.Quantile)
+	}
+
+	cfg = smoothingConfig(&aviatorv1alpha1.SmoothingSpec{HalfLife: metav1.Duration{Duration: 5 * time.Second}})
+	if cfg.HalfLife != 5*time.Second {
+		t.Errorf("half-life = %s, want the set 5s", cfg.HalfLife)
+	}
+}
+
+func TestDrainDefaultDuration(t *testing.T) {
+	r := &AviatorPolicyReconciler{drainers: make(map[string]*endpointslice.DrainTracker)}
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		Drain: &aviatorv1alpha1.DrainSpec{},
+	}}
+	ep := endpointslice.PodEndpoint{PodName: "web-1", PodIP: "10.0.0.1"}
+	pods := map[string]corev1.Pod{"10.0.0.1": {ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}}
+
+	r.drain(policy, "shop/web", []endpointslice.PodEndpoint{ep}, pods)
+	added := r.drain(policy, "shop/web", nil, pods)
+	if len(added) != 1 || len(policy.Status.DrainingPods) != 1 {
+		t.Fatalf("a deselected pod should drain with the default duration, got %+v", policy.Status.DrainingPods)
+	}
+	info := policy.Status.DrainingPods[0]
+	if got := info.Until.Sub(info.Since.Time); got != 30*time.Second {
+		t.Errorf("drain period = %s, want the default 30s", got)
+	}
+}
+
+func TestDrainRequeue(t *testing.T) {
+	r := &AviatorPolicyReconciler{drainers: make(map[string]*endpointslice.DrainTracker)}
+	if got := r.drainRequeue("shop/web", time.Minute); got != time.Minute {
+		t.Errorf("requeue without drains = %s, want the interval", got)
+	}
+
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		Drain: &aviatorv1alpha1.DrainSpec{Duration: metav1.Duration{Duration: 10 * time.Second}},
+	}}
+	ep := endpointslice.PodEndpoint{PodName: "web-1", PodIP: "10.0.0.1"}
+	pods := map[string]corev1.Pod{"10.0.0.1": {ObjectMeta: metav1.ObjectMeta{Name: "web-1"}}}
+	r.drain(policy, "shop/web", []endpointslice.PodEndpoint{ep}, pods)
+	r.drain(policy, "shop/web", nil, pods)
+
+	if got := r.drainRequeue("shop/web", time.Minute); got > 10*time.Second || got < 9*time.Second {
+		t.Errorf("requeue = %s, want the end of the 10s drain", got)
+	}
+	if got := r.drainRequeue("shop/web", 5*time.Second); got != 5*time.Second {
+		t.Errorf("requeue = %s, want the shorter interval", got)
+	}
+}
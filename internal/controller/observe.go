@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// observing reports whether the policy is in observe (dry-run) mode.
+func observing(policy *aviatorv1alpha1.AviatorPolicy) bool {
+	return policy.Spec.Mode == aviatorv1alpha1.PolicyModeObserve
+}
+
+// observe records the pods the policy would route to, and the diff against
+// the Service's current endpoints, in status. An Event is emitted whenever
+// the diff changes. Routing left over from enforce mode is undone first, so
+// an observing policy never affects traffic.
+func (r *AviatorPolicyReconciler) observe(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+	selected []latency.PodRanking,
+) error {
+	if _, err := r.restoreService(ctx, policy, service); err != nil {
+		return err
+	}
+	// Policies that enforced before status.routedService existed routed
+	// through the target's own name.
+	routed := policy.Status.RoutedService
+	if routed == "" {
+		routed = policy.Spec.TargetRef.Name
+	}
+	if err := r.releaseRoutedService(ctx, policy, routed); err != nil {
+		return err
+	}
+	policy.Status.RoutedService = ""
+
+	current, err := r.EndpointSliceManager.ServingPods(ctx, service.Namespace, service.Name)
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(selected))
+	for _, s := range selected {
+		names = append(names, s.PodName)
+	}
+	sort.Strings(names)
+
+	observed := &aviatorv1alpha1.ObservedRouting{
+		SelectedPods: names,
+		Added:        difference(names, current),
+		Removed:      difference(current, names),
+	}
+	prev := policy.Status.Observed
+	if prev == nil || !slices.Equal(prev.Added, observed.Added) || !slices.Equal(prev.Removed, observed.Removed) {
+		r.eventf(policy, corev1.EventTypeNormal, "ObservedSelection",
+			"Would route to %d pods: add %s, remove %s",
+			len(names), formatPodList(observed.Added), formatPodList(observed.Removed))
+	}
+	policy.Status.Observed = observed
+	return nil
+}
+
+// difference returns the names in a that are not in b.
+func difference(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, name := range b {
+		in[name] = true
+	}
+	var diff []string
+	for _, name := range a {
+		if !in[name] {
+			diff = append(diff, name)
+		}
+	}
+	return diff
+}
+
+// formatPodList formats pod names for an Event message, truncated to
+// maxStatusPodEntries names.
+func formatPodList(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	if len(names) <= maxStatusPodEntries {
+		return "[" + strings.Join(names, ", ") + "]"
+	}
+	return fmt.Sprintf("[%s, and %d more]",
+		strings.Join(names[:maxStatusPodEntries], ", "), len(names)-maxStatusPodEntries)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/endpointslice"
+	"aviator/internal/latency"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestObserve(t *testing.T) {
+	ready := true
+	notReady := false
+	kubeSlice := routingSlice("web-abcde", "endpointslice-controller.k8s.io")
+	kubeSlice.Endpoints = []discoveryv1.Endpoint{
+		{Addresses: []string{"10.0.0.1"}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-a"}},
+		{Addresses: []string{"10.0.0.2"}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-b"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready}},
+		{Addresses: []string{"10.0.0.9"}, TargetRef: &corev1.ObjectReference{Kind: "Pod", Name: "pod-z"},
+			Conditions: discoveryv1.EndpointConditions{Ready: &notReady}},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = aviatorv1alpha1.AddToScheme(scheme)
+	var sliceWrites int
+	countSlice := func(obj client.Object) {
+		if _, ok := obj.(*discoveryv1.EndpointSlice); ok {
+			sliceWrites++
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(routingService(), kubeSlice).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				countSlice(obj)
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				countSlice(obj)
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				countSlice(obj)
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{
+		Client:               c,
+		Scheme:               scheme,
+		Recorder:             recorder,
+		EndpointSliceManager: endpointslice.NewManager(c, logr.Discard()),
+	}
+
+	policy := takeOverPolicy()
+	policy.Spec.Mode = aviatorv1alpha1.PolicyModeObserve
+	selected := []latency.PodRanking{{PodName: "pod-c"}, {PodName: "pod-b"}}
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := r.observe(ctx, policy, getService(t, r), selected); err != nil {
+			t.Fatalf("observe %d: %v", i, err)
+		}
+	}
+
+	observed := policy.Status.Observed
+	if observed == nil {
+		t.Fatal("status.observed was not set")
+	}
+	if !slices.Equal(observed.SelectedPods, []string{"pod-b", "pod-c"}) {
+		t.Errorf("selectedPods = %v, want [pod-b pod-c]", observed.SelectedPods)
+	}
+	if !slices.Equal(observed.Added, []string{"pod-c"}) || !slices.Equal(observed.Removed, []string{"pod-a"}) {
+		t.Errorf("added = %v, removed = %v; want [pod-c] and [pod-a]", observed.Added, observed.Removed)
+	}
+	if sliceWrites != 0 {
+		t.Errorf("observe wrote %d EndpointSlices, want none", sliceWrites)
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("got %d events, want 1 for an unchanged diff", len(recorder.Events))
+	}
+	if names := sliceNames(t, r); len(names) != 1 || !names["web-abcde"] {
+		t.Errorf("slices = %v, want the Service's own slice untouched", names)
+	}
+}
+
+func TestObserve_UndoesEnforceRouting(t *testing.T) {
+	ctx := context.Background()
+	policy := takeOverPolicy()
+	r := newRoutingReconciler(routingService(), routingSlice("aviator-web", endpointslice.ManagedByValue))
+	if _, err := r.ensureRouting(ctx, policy, getService(t, r)); err != nil {
+		t.Fatal(err)
+	}
+	policy.Status.RoutedService = "web"
+
+	policy.Spec.Mode = aviatorv1alpha1.PolicyModeObserve
+	if err := r.observe(ctx, policy, getService(t, r), nil); err != nil {
+		t.Fatal(err)
+	}
+	if svc := getService(t, r); svc.Spec.Selector["app"] != "web" {
+		t.Errorf("selector = %v, want the taken-over Service restored", svc.Spec.Selector)
+	}
+	if names := sliceNames(t, r); len(names) != 0 || policy.Status.RoutedService != "" {
+		t.Errorf("slices = %v, routedService = %q; want Aviator's routing removed", names, policy.Status.RoutedService)
+	}
+}
+
+func TestObserve_UndoesLegacyRouting(t *testing.T) {
+	// Written before status.routedService was recorded.
+	r := newRoutingReconciler(routingService(), routingSlice("aviator-web", endpointslice.ManagedByValue))
+	policy := takeOverPolicy()
+	policy.Spec.Mode = aviatorv1alpha1.PolicyModeObserve
+	if err := r.observe(context.Background(), policy, getService(t, r), nil); err != nil {
+		t.Fatal(err)
+	}
+	if names := sliceNames(t, r); len(names) != 0 {
+		t.Errorf("slices = %v, want Aviator's slice for the target removed", names)
+	}
+}
+
+func TestDifference(t *testing.T) {
+	tests := []struct {
+		a, b, want []string
+	}{
+		{nil, []string{"a"}, nil},
+		{[]string{"a", "b"}, nil, []string{"a", "b"}},
+		{[]string{"a", "b", "c"}, []string{"b"}, []string{"a", "c"}},
+		{[]string{"a"}, []string{"a"}, nil},
+	}
+	for _, tt := range tests {
+		if got := difference(tt.a, tt.b); !slices.Equal(got, tt.want) {
+			t.Errorf("difference(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFormatPodList(t *testing.T) {
+	if got := formatPodList(nil); got != "none" {
+		t.Errorf("formatPodList(nil) = %q, want none", got)
+	}
+	if got := formatPodList([]string{"a", "b"}); got != "[a, b]" {
+		t.Errorf("formatPodList = %q, want [a, b]", got)
+	}
+	names := make([]string, maxStatusPodEntries+3)
+	for i := range names {
+		names[i] = "p"
+	}
+	want := "[" + strings.Repeat("p, ", maxStatusPodEntries) + "and 3 more]"
+	if got := formatPodList(names); got != want {
+		t.Errorf("formatPodList = %q, want %q", got, want)
+	}
+}
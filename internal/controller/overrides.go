@@ -0,0 +1,216 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// manualOverrides holds the operator overrides read from pod and policy
+// annotations, keyed by pod IP.
+type manualOverrides struct {
+	pinned     map[string]bool
+	excluded   map[string]bool
+	forceEject map[string]bool
+	// ignoredForceEjects counts force-ejects of pods that exist, while the
+	// circuit breaker is disabled.
+	ignoredForceEjects int
+}
+
+// readOverrides reads the pin and exclude annotations of the pods and the
+// policy's force-eject list. Exclusion takes precedence over pinning, and
+// force-ejects are ignored while the circuit breaker is disabled.
+func readOverrides(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	podIPs []string,
+	podIPMap map[string]corev1.Pod,
+) manualOverrides {
+	o := manualOverrides{
+		pinned:     make(map[string]bool),
+		excluded:   make(map[string]bool),
+		forceEject: make(map[string]bool),
+	}
+	forced := make(map[string]bool)
+	for _, name := range strings.Split(policy.Annotations[aviatorv1alpha1.ForceEjectAnnotation], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			forced[name] = true
+		}
+	}
+	for _, ip := range podIPs {
+		pod := podIPMap[ip]
+		switch {
+		case pod.Annotations[aviatorv1alpha1.ExcludeAnnotation] == "true":
+			o.excluded[ip] = true
+		case pod.Annotations[aviatorv1alpha1.PinAnnotation] == "true":
+			o.pinned[ip] = true
+		}
+		if !forced[pod.Name] {
+			continue
+		}
+		if cb := policy.Spec.CircuitBreaker; cb != nil && cb.Enabled {
+			o.forceEject[ip] = true
+		} else {
+			o.ignoredForceEjects++
+		}
+	}
+	return o
+}
+
+// byPod returns the override shown for each pod in the report. A pod that
+// is both excluded or pinned and force-ejected shows the former, which
+// decides its selection.
+func (o manualOverrides) byPod() map[string]string {
+	out := make(map[string]string, len(o.pinned)+len(o.excluded)+len(o.forceEject))
+	for ip := range o.forceEject {
+		out[ip] = aviatorv1alpha1.ManualOverrideForceEject
+	}
+	for ip := range o.pinned {
+		out[ip] = aviatorv1alpha1.ManualOverridePin
+	}
+	for ip := range o.excluded {
+		out[ip] = aviatorv1alpha1.ManualOverrideExclude
+	}
+	return out
+}
+
+// withoutExcluded drops excluded pods from rankings.
+func (o manualOverrides) withoutExcluded(rankings []latency.PodRanking) []latency.PodRanking {
+	if len(o.excluded) == 0 {
+		return rankings
+	}
+	kept := make([]latency.PodRanking, 0, len(rankings))
+	for _, rank := range rankings {
+		if !o.excluded[rank.PodIP] {
+			kept = append(kept, rank)
+		}
+	}
+	return kept
+}
+
+// pinnedSelection returns the pinned pods, with their ranking when they
+// have one.
+func (o manualOverrides) pinnedSelection(ev evaluation) []latency.PodRanking {
+	if len(o.pinned) == 0 {
+		return nil
+	}
+	ranked := make(map[string]latency.PodRanking, len(ev.ranked))
+	for _, rank := range ev.ranked {
+		ranked[rank.PodIP] = rank
+	}
+	var pinned []latency.PodRanking
+	for _, ip := range ev.podIPs {
+		if !o.pinned[ip] {
+			continue
+		}
+		rank, ok := ranked[ip]
+		if !ok {
+			pod := ev.podIPMap[ip]
+			rank = latency.PodRanking{PodName: pod.Name, PodIP: ip, Zone: ev.zones[pod.Spec.NodeName]}
+		}
+		pinned = append(pinned, rank)
+	}
+	return pinned
+}
+
+// status lists the overrides for status, ordered by pod name.
+func (o manualOverrides) status(podIPMap map[string]corev1.Pod) []aviatorv1alpha1.ManualOverride {
+	var entries []aviatorv1alpha1.ManualOverride
+	add := func(ips map[string]bool, overrideType string) {
+		for ip := range ips {
+			entries = append(entries, aviatorv1alpha1.ManualOverride{Pod: podIPMap[ip].Name, Type: overrideType})
+		}
+	}
+	add(o.pinned, aviatorv1alpha1.ManualOverridePin)
+	add(o.excluded, aviatorv1alpha1.ManualOverrideExclude)
+	add(o.forceEject, aviatorv1alpha1.ManualOverrideForceEject)
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Pod != entries[j].Pod {
+			return entries[i].Pod < entries[j].Pod
+		}
+		return entries[i].Type < entries[j].Type
+	})
+	return entries
+}
+
+// recordOverrides reports the applied overrides in status and the
+// Overridden condition, and emits an Event when they change. It reports
+// whether they changed since the last evaluation.
+func (r *AviatorPolicyReconciler) recordOverrides(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	overrides manualOverrides,
+	podIPMap map[string]corev1.Pod,
+) bool {
+	entries := overrides.status(podIPMap)
+	ignoredForceEjects := overrides.ignoredForceEjects
+	counts := make(map[string]int)
+	for _, e := range entries {
+		counts[e.Type]++
+	}
+	summary := fmt.Sprintf("%d pinned, %d excluded, %d force-ejected",
+		counts[aviatorv1alpha1.ManualOverridePin],
+		counts[aviatorv1alpha1.ManualOverrideExclude],
+		counts[aviatorv1alpha1.ManualOverrideForceEject])
+	if ignoredForceEjects > 0 {
+		summary += fmt.Sprintf("; %d force-eject(s) ignored, the circuit breaker is disabled", ignoredForceEjects)
+	}
+
+	status, reason, message := metav1.ConditionFalse, "NoOverrides", "No manual overrides applied"
+	if len(entries) > 0 || ignoredForceEjects > 0 {
+		status, reason, message = metav1.ConditionTrue, "ManualOverrides", "Manual overrides applied: "+summary
+	}
+	capped := entries
+	if len(capped) > maxStatusPodEntries {
+		capped = capped[:maxStatusPodEntries]
+	}
+	// Status is capped, so the counts in the message are compared too.
+	prev := meta.FindStatusCondition(policy.Status.Conditions, "Overridden")
+	changed := !slices.Equal(capped, policy.Status.ManualOverrides) ||
+		(prev == nil && status == metav1.ConditionTrue) ||
+		(prev != nil && prev.Message != message)
+	if changed {
+		r.eventf(policy, corev1.EventTypeNormal, "ManualOverridesChanged", "Manual overrides: %s", summary)
+	}
+	policy.Status.ManualOverrides = capped
+	r.setCondition(policy, "Overridden", status, reason, message)
+	return changed
+}
+
+// resetBreaker resets the circuit breaker when the policy's reset
+// annotation has a value it has not acted on yet.
+func (r *AviatorPolicyReconciler) resetBreaker(policy *aviatorv1alpha1.AviatorPolicy, breaker *circuitbreaker.Breaker) {
+	token := policy.Annotations[aviatorv1alpha1.ResetCircuitBreakerAnnotation]
+	if token == "" || token == policy.Status.CircuitBreakerReset {
+		return
+	}
+	if breaker != nil {
+		breaker.Reset()
+		r.eventf(policy, corev1.EventTypeNormal, "CircuitBreakerReset",
+			"Circuit breaker reset by the %s annotation; all pods re-admitted", aviatorv1alpha1.ResetCircuitBreakerAnnotation)
+	}
+	policy.Status.CircuitBreakerReset = token
+}
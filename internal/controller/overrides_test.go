@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func annotatedPod(name string, annotations map[string]string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+}
+
+func TestReadOverrides(t *testing.T) {
+	podIPMap := map[string]corev1.Pod{
+		"a": annotatedPod("a", map[string]string{aviatorv1alpha1.PinAnnotation: "true"}),
+		"b": annotatedPod("b", map[string]string{aviatorv1alpha1.ExcludeAnnotation: "true"}),
+		"c": annotatedPod("c", map[string]string{
+			aviatorv1alpha1.PinAnnotation:     "true",
+			aviatorv1alpha1.ExcludeAnnotation: "true",
+		}),
+		"d": annotatedPod("d", nil),
+	}
+	policy := &aviatorv1alpha1.AviatorPolicy{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{aviatorv1alpha1.ForceEjectAnnotation: "d, missing"},
+	}}
+	ips := []string{"a", "b", "c", "d"}
+
+	o := readOverrides(policy, ips, podIPMap)
+	if !o.pinned["a"] || o.pinned["c"] || !o.excluded["b"] || !o.excluded["c"] {
+		t.Errorf("exclusion should take precedence over pinning: pinned %v, excluded %v", o.pinned, o.excluded)
+	}
+	if len(o.forceEject) != 0 || o.ignoredForceEjects != 1 {
+		t.Errorf("force-eject without a circuit breaker: got %v, %d ignored", o.forceEject, o.ignoredForceEjects)
+	}
+
+	policy.Spec.CircuitBreaker = &aviatorv1alpha1.CircuitBreakerSpec{Enabled: true}
+	o = readOverrides(policy, ips, podIPMap)
+	if !o.forceEject["d"] || len(o.forceEject) != 1 {
+		t.Errorf("forceEject = %v, want d", o.forceEject)
+	}
+
+	ranked := []latency.PodRanking{{PodName: "b", PodIP: "b"}, {PodName: "a", PodIP: "a", Stats: latency.Stats{P99: time.Millisecond}}}
+	if kept := o.withoutExcluded(ranked); len(kept) != 1 || kept[0].PodIP != "a" {
+		t.Errorf("withoutExcluded = %v", kept)
+	}
+	pinned := o.pinnedSelection(evaluation{podIPs: ips, podIPMap: podIPMap, ranked: ranked})
+	if len(pinned) != 1 || pinned[0].Stats.P99 != time.Millisecond {
+		t.Errorf("pinnedSelection = %v, want a with its ranking", pinned)
+	}
+}
+
+func TestRecordOverrides(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{Recorder: recorder}
+	policy := &aviatorv1alpha1.AviatorPolicy{}
+	podIPMap := map[string]corev1.Pod{"a": annotatedPod("a", nil)}
+
+	if r.recordOverrides(policy, manualOverrides{}, podIPMap) {
+		t.Error("no overrides should not count as a change")
+	}
+	o := manualOverrides{pinned: map[string]bool{"a": true}}
+	if !r.recordOverrides(policy, o, podIPMap) {
+		t.Error("pinning a pod should count as a change")
+	}
+	if r.recordOverrides(policy, o, podIPMap) {
+		t.Error("unchanged overrides should not count as a change")
+	}
+	want := []aviatorv1alpha1.ManualOverride{{Pod: "a", Type: aviatorv1alpha1.ManualOverridePin}}
+	if got := policy.Status.ManualOverrides; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("status.manualOverrides = %v, want %v", got, want)
+	}
+	if !meta.IsStatusConditionTrue(policy.Status.Conditions, "Overridden") {
+		t.Error("Overridden should be True")
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("got %d events, want 1", len(recorder.Events))
+	}
+}
+
+func TestResetBreaker(t *testing.T) {
+	r := &AviatorPolicyReconciler{Recorder: record.NewFakeRecorder(10)}
+	breaker := circuitbreaker.New(circuitbreaker.Config{P99Threshold: time.Millisecond, ConsecutiveViolations: 1})
+	policy := &aviatorv1alpha1.AviatorPolicy{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{aviatorv1alpha1.ResetCircuitBreakerAnnotation: "1"},
+	}}
+
+	breaker.ForceEject("a")
+	r.resetBreaker(policy, breaker)
+	if breaker.IsEjected("a") || policy.Status.CircuitBreakerReset != "1" {
+		t.Fatalf("reset not applied: ejected %v, recorded %q", breaker.IsEjected("a"), policy.Status.CircuitBreakerReset)
+	}
+
+	breaker.ForceEject("a")
+	r.resetBreaker(policy, breaker)
+	if !breaker.IsEjected("a") {
+		t.Error("the same annotation value should not reset again")
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// maxReportPodEntries caps the pods listed in a report. An entry with every
+// field set and a maximum-length pod name encodes to about 700 bytes, so a
+// full report stays under 1.5 MiB, etcd's default request size limit, with
+// room to spare.
+const maxReportPodEntries = 2000
+
+// evaluation is what a policy report records about one evaluation.
+type evaluation struct {
+	// podIPs lists every pod considered.
+	podIPs   []string
+	podIPMap map[string]corev1.Pod
+	zones    map[string]string
+	// ranked holds every ranked pod, best first, before any was excluded.
+	ranked   []latency.PodRanking
+	selected []latency.PodRanking
+	// excluded maps pod IPs to the reason a pipeline stage left them out.
+	excluded map[string]string
+	// overrides maps pod IPs to the manual override applied to them.
+	overrides map[string]string
+	ejected   int
+	breaker   *circuitbreaker.Breaker
+}
+
+// exclude records why a pod was left out, keeping the first stage's reason.
+func (e *evaluation) exclude(ip, reason string) {
+	if _, ok := e.excluded[ip]; !ok {
+		e.excluded[ip] = reason
+	}
+}
+
+// podReports lists every pod of the evaluation, ranked pods in rank order
+// and then those that were not ranked, and sums them up.
+func podReports(
+	policy *aviatorv1alpha1.AviatorPolicy,
+	ev evaluation,
+) ([]aviatorv1alpha1.PodReport, aviatorv1alpha1.ReportSummary) {
+	selected := make(map[string]bool, len(ev.selected))
+	for _, s := range ev.selected {
+		selected[s.PodIP] = true
+	}
+
+	entries := make([]aviatorv1alpha1.PodReport, 0, len(ev.podIPs))
+	ranked := make(map[string]bool, len(ev.ranked))
+	for i, rank := range ev.ranked {
+		ranked[rank.PodIP] = true
+		entry := aviatorv1alpha1.PodReport{
+			Name:      rank.PodName,
+			PodIP:     rank.PodIP,
+			Zone:      rank.Zone,
+			Rank:      int32(i + 1),
+			P50:       metav1.Duration{Duration: rank.Stats.P50},
+			P99:       metav1.Duration{Duration: rank.Stats.P99},
+			Estimated: rank.Estimated,
+		}
+		if rank.ScoreBreakdown != nil {
+			score := int32(rank.Score * scoreScale)
+			entry.Score = &score
+		}
+		entries = append(entries, entry)
+	}
+	for _, ip := range ev.podIPs {
+		if ranked[ip] {
+			continue
+		}
+		pod := ev.podIPMap[ip]
+		entries = append(entries, aviatorv1alpha1.PodReport{
+			Name:  pod.Name,
+			PodIP: ip,
+			Zone:  ev.zones[pod.Spec.NodeName],
+		})
+	}
+
+	summary := aviatorv1alpha1.ReportSummary{
+		EvaluationTime:   metav1.Now(),
+		PolicyGeneration: policy.Generation,
+		Total:            int32(len(entries)),
+		Selected:         int32(len(selected)),
+		Ejected:          int32(ev.ejected),
+	}
+	for i := range entries {
+		entry := &entries[i]
+		entry.Override = ev.overrides[entry.PodIP]
+		if ev.breaker != nil {
+			state := circuitbreaker.StateClosed
+			if ps, ok := ev.breaker.GetState(entry.PodIP); ok {
+				state = ps.State
+				entry.CircuitBreakerCause = string(ps.Cause)
+			}
+			entry.CircuitBreaker = state.String()
+		}
+		if selected[entry.PodIP] {
+			entry.Selected = true
+			continue
+		}
+		switch reason := ev.excluded[entry.PodIP]; {
+		case reason != "":
+			entry.Reason = reason
+			entry.Message = exclusionMessage(policy, reason)
+			if reason == aviatorv1alpha1.ExclusionCircuitBroken && entry.CircuitBreakerCause != "" {
+				entry.Message += " (" + entry.CircuitBreakerCause + ")"
+			}
+		case entry.Rank == 0:
+			entry.Reason = aviatorv1alpha1.ExclusionUnmeasured
+			entry.Message = exclusionMessage(policy, entry.Reason)
+		default:
+			entry.Reason = aviatorv1alpha1.ExclusionNotSelected
+			entry.Message = exclusionMessage(policy, entry.Reason)
+		}
+	}
+
+	if len(entries) > maxReportPodEntries {
+		summary.Truncated = int32(len(entries) - maxReportPodEntries)
+		entries = entries[:maxReportPodEntries]
+	}
+	return entries, summary
+}
+
+// exclusionMessage explains an exclusion reason in terms of the policy.
+func exclusionMessage(policy *aviatorv1alpha1.AviatorPolicy, reason string) string {
+	switch reason {
+	case aviatorv1alpha1.ExclusionCircuitBroken:
+		return "ejected by the circuit breaker"
+	case aviatorv1alpha1.ExclusionHysteresis:
+		return "held out by hysteresis until its latency recovers"
+	case aviatorv1alpha1.ExclusionDampened:
+		return "selected, but dampening is holding back the change"
+	case aviatorv1alpha1.ExclusionUnmeasured:
+		return "no latency data and not warming up"
+	case aviatorv1alpha1.ExclusionManual:
+		return "excluded by the " + aviatorv1alpha1.ExcludeAnnotation + " annotation"
+	}
+
+	sel := policy.Spec.Selection
+	pct := defaultPercentage
+	if sel.Percentage != nil {
+		pct = *sel.Percentage
+	}
+	switch sel.Mode {
+	case aviatorv1alpha1.SelectionModeTopN:
+		n := int32(3)
+		if sel.TopN != nil {
+			n = *sel.TopN
+		}
+		return fmt.Sprintf("not among the top %d", n)
+	case aviatorv1alpha1.SelectionModeThreshold:
+		return fmt.Sprintf("P99 above %s", policy.Spec.LatencyThreshold.Duration)
+	case aviatorv1alpha1.SelectionModeOutlier:
+		return "latency outlier"
+	case aviatorv1alpha1.SelectionModeScore:
+		if sel.TopN != nil {
+			return fmt.Sprintf("score not among the top %d", *sel.TopN)
+		}
+		return fmt.Sprintf("score not in the best %d%%", pct)
+	default:
+		return fmt.Sprintf("not in the fastest %d%%", pct)
+	}
+}
+
+// writeReport creates or updates the policy's report. The report has the
+// policy's name and is owned by it, so it is garbage collected with it.
+func (r *AviatorPolicyReconciler) writeReport(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	ev evaluation,
+) error {
+	entries, summary := podReports(policy, ev)
+	report := &aviatorv1alpha1.AviatorPolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: policy.Name, Namespace: policy.Namespace},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, report, func() error {
+		report.Summary = summary
+		report.Pods = entries
+		return controllerutil.SetControllerReference(policy, report, r.Scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
+
+// markDampened records the pods the selection wanted but dampening held out
+// of the applied set. Dampening is the last stage, so its reason wins.
+func markDampened(ev *evaluation, proposed []string, applied []latency.PodRanking) {
+	in := make(map[string]bool, len(applied))
+	for _, a := range applied {
+		in[a.PodIP] = true
+	}
+	for _, ip := range proposed {
+		if !in[ip] {
+			ev.excluded[ip] = aviatorv1alpha1.ExclusionDampened
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/latency"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodReports(t *testing.T) {
+	topN := int32(1)
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		Selection: aviatorv1alpha1.SelectionPolicy{Mode: aviatorv1alpha1.SelectionModeTopN, TopN: &topN},
+	}}
+	rank := func(name string, p99 time.Duration) latency.PodRanking {
+		return latency.PodRanking{PodName: name, PodIP: name, Stats: latency.Stats{P99: p99}}
+	}
+	ev := evaluation{
+		podIPs: []string{"a", "b", "c", "d", "e"},
+		podIPMap: map[string]corev1.Pod{
+			"d": {ObjectMeta: metav1.ObjectMeta{Name: "d"}},
+			"e": {ObjectMeta: metav1.ObjectMeta{Name: "e"}},
+		},
+		ranked:   []latency.PodRanking{rank("a", time.Millisecond), rank("b", 2*time.Millisecond), rank("c", time.Second)},
+		selected: []latency.PodRanking{rank("a", time.Millisecond), {PodName: "e", PodIP: "e"}},
+		excluded: map[string]string{"c": aviatorv1alpha1.ExclusionCircuitBroken},
+		ejected:  1,
+	}
+
+	entries, summary := podReports(policy, ev)
+	if summary.Total != 5 || summary.Selected != 2 || summary.Ejected != 1 || summary.Truncated != 0 {
+		t.Errorf("summary = %+v", summary)
+	}
+	want := []struct {
+		name     string
+		rank     int32
+		selected bool
+		reason   string
+	}{
+		{"a", 1, true, ""},
+		{"b", 2, false, aviatorv1alpha1.ExclusionNotSelected},
+		{"c", 3, false, aviatorv1alpha1.ExclusionCircuitBroken},
+		{"d", 0, false, aviatorv1alpha1.ExclusionUnmeasured},
+		{"e", 0, true, ""},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(entries), len(want))
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Name != w.name || e.Rank != w.rank || e.Selected != w.selected || e.Reason != w.reason {
+			t.Errorf("entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+	if msg := entries[1].Message; msg != "not among the top 1" {
+		t.Errorf("NotSelected message = %q", msg)
+	}
+}
+
+func TestPodReportsTruncates(t *testing.T) {
+	ev := evaluation{excluded: map[string]string{}}
+	for i := 0; i < maxReportPodEntries+3; i++ {
+		ip := fmt.Sprintf("10.0.%d.%d", i/256, i%256)
+		ev.podIPs = append(ev.podIPs, ip)
+		ev.ranked = append(ev.ranked, latency.PodRanking{PodName: ip, PodIP: ip})
+	}
+	entries, summary := podReports(&aviatorv1alpha1.AviatorPolicy{}, ev)
+	if len(entries) != maxReportPodEntries || summary.Truncated != 3 || summary.Total != maxReportPodEntries+3 {
+		t.Errorf("got %d entries, summary %+v", len(entries), summary)
+	}
+}
+
+func TestFullReportSize(t *testing.T) {
+	// etcd rejects requests over 1.5 MiB by default.
+	const limit = 3 << 19
+
+	policy := &aviatorv1alpha1.AviatorPolicy{}
+	ev := evaluation{excluded: map[string]string{}, overrides: map[string]string{}}
+	for i := 0; i < maxReportPodEntries+1; i++ {
+		ip := fmt.Sprintf("fd00:1234:5678:9abc:def0:%04x:%04x:%04x", i, i, i)
+		name := fmt.Sprintf("%s-%05d", strings.Repeat("x", 247), i)
+		ev.podIPs = append(ev.podIPs, ip)
+		ev.ranked = append(ev.ranked, latency.PodRanking{
+			PodName: name, PodIP: ip, Zone: "europe-west1-b-long-zone-name",
+			Stats:     latency.Stats{P50: 123456789 * time.Nanosecond, P99: 987654321 * time.Nanosecond},
+			Estimated: true, Score: 0.123456, ScoreBreakdown: map[latency.Signal]float64{},
+		})
+		ev.excluded[ip] = aviatorv1alpha1.ExclusionCircuitBroken
+		ev.overrides[ip] = aviatorv1alpha1.ExclusionManual
+	}
+	entries, summary := podReports(policy, ev)
+	for i := range entries {
+		entries[i].CircuitBreaker = "HalfOpen"
+		entries[i].CircuitBreakerCause = "error rate 100% over the last 10 requests"
+		entries[i].Message += " (" + entries[i].CircuitBreakerCause + ")"
+	}
+
+	report := aviatorv1alpha1.AviatorPolicyReport{
+		ObjectMeta: metav1.ObjectMeta{Name: strings.Repeat("p", 253), Namespace: strings.Repeat("n", 63)},
+		Summary:    summary,
+		Pods:       entries,
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) >= limit {
+		t.Errorf("a full report encodes to %d bytes, over the %d byte limit", len(data), limit)
+	}
+	t.Logf("full report: %d bytes, %d per entry", len(data), len(data)/len(entries))
+}
+
+func TestMarkDampened(t *testing.T) {
+	ev := evaluation{excluded: map[string]string{"b": aviatorv1alpha1.ExclusionCircuitBroken}}
+	markDampened(&ev, []string{"a", "b"}, []latency.PodRanking{{PodIP: "a"}})
+	if got := ev.excluded["b"]; got != aviatorv1alpha1.ExclusionDampened {
+		t.Errorf("b excluded as %q, want Dampened", got)
+	}
+	if _, ok := ev.excluded["a"]; ok {
+		t.Error("applied pod a should not be excluded")
+	}
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// originalSelectorAnnotation stores a taken-over Service's selector as JSON.
+	originalSelectorAnnotation = "aviator.io/original-selector"
+	// routingOwnerAnnotation names the policy that took over a Service.
+	routingOwnerAnnotation = "aviator.io/routing-owner"
+
+	derivedServiceSuffix = "-fast"
+
+	// restoreWaitTimeout bounds how long deletion of a takeOver policy waits
+	// for kube-controller-manager to recreate the Service's own slices before
+	// removing Aviator's.
+	restoreWaitTimeout  = 30 * time.Second
+	restoreWaitInterval = 2 * time.Second
+)
+
+// routingMode returns the policy's routing mode, defaulting to parallel.
+// Workload targets always route through their owned fronting Service,
+// which is a derived Service in all but name.
+func routingMode(policy *aviatorv1alpha1.AviatorPolicy) aviatorv1alpha1.RoutingMode {
+	if !targetsService(policy) {
+		return aviatorv1alpha1.RoutingModeDerivedService
+	}
+	if policy.Spec.Routing == nil || policy.Spec.Routing.Mode == "" {
+		return aviatorv1alpha1.RoutingModeParallel
+	}
+	return policy.Spec.Routing.Mode
+}
+
+// derivedServiceName returns the name of the policy's derived Service.
+func derivedServiceName(policy *aviatorv1alpha1.AviatorPolicy) string {
+	if policy.Spec.Routing != nil && policy.Spec.Routing.ServiceName != "" {
+		return policy.Spec.Routing.ServiceName
+	}
+	return policy.Spec.TargetRef.Name + derivedServiceSuffix
+}
+
+// serviceSelector returns the pod selector of a Service, including the
+// original selector of a Service that Aviator has taken over.
+func serviceSelector(service *corev1.Service) (map[string]string, error) {
+	raw, ok := service.Annotations[originalSelectorAnnotation]
+	if !ok {
+		return service.Spec.Selector, nil
+	}
+	var selector map[string]string
+	if err := json.Unmarshal([]byte(raw), &selector); err != nil {
+		return nil, fmt.Errorf("parsing %s annotation: %w", originalSelectorAnnotation, err)
+	}
+	return selector, nil
+}
+
+// ensureRouting prepares the Service that Aviator's EndpointSlices are
+// attached to and returns it. Anything left over from a routing mode the
+// policy used before is undone.
+func (r *AviatorPolicyReconciler) ensureRouting(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+) (*corev1.Service, error) {
+	mode := routingMode(policy)
+	if mode != aviatorv1alpha1.RoutingModeTakeOver {
+		if _, err := r.restoreService(ctx, policy, service); err != nil {
+			return nil, err
+		}
+	}
+
+	routed := service
+	switch mode {
+	case aviatorv1alpha1.RoutingModeDerivedService:
+		derived, err := r.ensureDerivedService(ctx, policy, service)
+		if err != nil {
+			return nil, err
+		}
+		routed = derived
+	case aviatorv1alpha1.RoutingModeTakeOver:
+		if err := r.takeOverService(ctx, policy, service); err != nil {
+			return nil, err
+		}
+	}
+
+	if prev := policy.Status.RoutedService; prev != "" && prev != routed.Name {
+		if err := r.releaseRoutedService(ctx, policy, prev); err != nil {
+			return nil, err
+		}
+	}
+	return routed, nil
+}
+
+// ensureDerivedService creates or updates the selectorless Service that owns
+// only Aviator's slices.
+func (r *AviatorPolicyReconciler) ensureDerivedService(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+) (*corev1.Service, error) {
+	ports := make([]corev1.ServicePort, 0, len(service.Spec.Ports))
+	for _, p := range service.Spec.Ports {
+		p.NodePort = 0
+		ports = append(ports, p)
+	}
+
+	var derived corev1.Service
+	key := types.NamespacedName{Name: derivedServiceName(policy), Namespace: policy.Namespace}
+	err := r.Get(ctx, key, &derived)
+	if errors.IsNotFound(err) {
+		derived = corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        key.Name,
+				Namespace:   key.Namespace,
+				Labels:      service.Labels,
+				Annotations: topologyAnnotations(service),
+			},
+			Spec: corev1.ServiceSpec{
+				Type:                corev1.ServiceTypeClusterIP,
+				Ports:               ports,
+				TrafficDistribution: service.Spec.TrafficDistribution,
+			},
+		}
+		if err := controllerutil.SetControllerReference(policy, &derived, r.Scheme); err != nil {
+			return nil, err
+		}
+		log.FromContext(ctx).Info("creating derived Service", "service", key)
+		if err := r.Create(ctx, &derived); err != nil {
+			return nil, fmt.Errorf("creating derived Service: %w", err)
+		}
+		return &derived, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting derived Service: %w", err)
+	}
+	if !metav1.IsControlledBy(&derived, policy) {
+		return nil, fmt.Errorf("service %s already exists and is not managed by this policy", key.Name)
+	}
+
+	if !equality.Semantic.DeepEqual(derived.Spec.Ports, ports) ||
+		!equality.Semantic.DeepEqual(derived.Spec.TrafficDistribution, service.Spec.TrafficDistribution) {
+		derived.Spec.Ports = ports
+		derived.Spec.TrafficDistribution = service.Spec.TrafficDistribution
+		if err := r.Update(ctx, &derived); err != nil {
+			return nil, fmt.Errorf("updating derived Service: %w", err)
+		}
+	}
+	return &derived, nil
+}
+
+// topologyAnnotations returns the topology-aware routing annotations of a
+// Service, so a derived Service keeps the same routing preferences.
+func topologyAnnotations(service *corev1.Service) map[string]string {
+	annotations := make(map[string]string)
+	for _, key := range []string{corev1.AnnotationTopologyMode, corev1.DeprecatedAnnotationTopologyAwareHints} {
+		if v, ok := service.Annotations[key]; ok {
+			annotations[key] = v
+		}
+	}
+	return annotations
+}
+
+// takeOverService removes the target Service's selector, saving it in an
+// annotation, and deletes the slices other controllers leave behind. The
+// Service's legacy Endpoints object is deleted too, as the mirroring
+// controller would otherwise copy it back into slices.
+func (r *AviatorPolicyReconciler) takeOverService(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+) error {
+	owner, owned := service.Annotations[routingOwnerAnnotation]
+	if owned && owner != policy.Name {
+		return fmt.Errorf("service %s is already taken over by policy %s", service.Name, owner)
+	}
+
+	if !owned {
+		if len(service.Spec.Selector) == 0 {
+			return fmt.Errorf("service %s has no selector to take over", service.Name)
+		}
+		selector, err := json.Marshal(service.Spec.Selector)
+		if err != nil {
+			return err
+		}
+		patch := client.MergeFrom(service.DeepCopy())
+		if service.Annotations == nil {
+			service.Annotations = make(map[string]string)
+		}
+		service.Annotations[originalSelectorAnnotation] = string(selector)
+		service.Annotations[routingOwnerAnnotation] = policy.Name
+		service.Spec.Selector = nil
+		log.FromContext(ctx).Info("taking over Service", "service", service.Name)
+		if err := r.Patch(ctx, service, patch); err != nil {
+			return fmt.Errorf("taking over Service: %w", err)
+		}
+	}
+
+	endpoints := &corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: service.Name, Namespace: service.Namespace}}
+	if err := r.Delete(ctx, endpoints); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting Endpoints: %w", err)
+	}
+	return r.EndpointSliceManager.RemoveOtherSlices(ctx, service.Namespace, service.Name)
+}
+
+// restoreService gives a Service taken over by the policy its selector back.
+// It reports whether the Service was restored.
+func (r *AviatorPolicyReconciler) restoreService(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+) (bool, error) {
+	if service.Annotations[routingOwnerAnnotation] != policy.Name {
+		return false, nil
+	}
+	selector, err := serviceSelector(service)
+	if err != nil {
+		return false, err
+	}
+
+	patch := client.MergeFrom(service.DeepCopy())
+	service.Spec.Selector = selector
+	delete(service.Annotations, originalSelectorAnnotation)
+	delete(service.Annotations, routingOwnerAnnotation)
+	log.FromContext(ctx).Info("restoring Service selector", "service", service.Name)
+	if err := r.Patch(ctx, service, patch); err != nil {
+		return false, fmt.Errorf("restoring Service: %w", err)
+	}
+	return true, nil
+}
+
+// releaseRoutedService removes the policy's slices from a Service it no
+// longer routes through, and deletes that Service if it was derived.
+func (r *AviatorPolicyReconciler) releaseRoutedService(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	name string,
+) error {
+	if err := r.EndpointSliceManager.Cleanup(ctx, policy.Namespace, name); err != nil {
+		return fmt.Errorf("cleaning up EndpointSlices: %w", err)
+	}
+	if targetsService(policy) && name == policy.Spec.TargetRef.Name {
+		return nil
+	}
+
+	var derived corev1.Service
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: policy.Namespace}, &derived)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(&derived, policy) {
+		return nil
+	}
+	log.FromContext(ctx).Info("deleting derived Service", "service", name)
+	if err := r.Delete(ctx, &derived); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("deleting derived Service: %w", err)
+	}
+	return nil
+}
+
+// releaseRouting undoes the policy's routing when it is deleted. A
+// taken-over Service gets its selector back, and Aviator's slices stay in
+// place until kube-controller-manager has recreated the Service's own (or
+// restoreWaitTimeout passes), so the Service never has no endpoints. It
+// returns a non-zero requeue delay while waiting.
+func (r *AviatorPolicyReconciler) releaseRouting(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+) (time.Duration, error) {
+	if !targetsService(policy) {
+		if prev := policy.Status.RoutedService; prev != "" {
+			return 0, r.releaseRoutedService(ctx, policy, prev)
+		}
+		return 0, nil
+	}
+
+	var service corev1.Service
+	err := r.Get(ctx, types.NamespacedName{Name: policy.Spec.TargetRef.Name, Namespace: policy.Namespace}, &service)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	if err == nil {
+		if _, err := r.restoreService(ctx, policy, &service); err != nil {
+			return 0, err
+		}
+		if len(service.Spec.Selector) > 0 && routingMode(policy) == aviatorv1alpha1.RoutingModeTakeOver &&
+			time.Since(policy.DeletionTimestamp.Time) < restoreWaitTimeout {
+			other, err := r.EndpointSliceManager.OtherSlices(ctx, service.Namespace, service.Name)
+			if err != nil {
+				return 0, err
+			}
+			if len(other) == 0 {
+				return restoreWaitInterval, nil
+			}
+		}
+	}
+
+	if err := r.EndpointSliceManager.Cleanup(ctx, policy.Namespace, policy.Spec.TargetRef.Name); err != nil {
+		return 0, fmt.Errorf("cleaning up EndpointSlice: %w", err)
+	}
+	if prev := policy.Status.RoutedService; prev != "" && prev != policy.Spec.TargetRef.Name {
+		if err := r.releaseRoutedService(ctx, policy, prev); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/endpointslice"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newRoutingReconciler(objs ...client.Object) *AviatorPolicyReconciler {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = aviatorv1alpha1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &AviatorPolicyReconciler{
+		Client:               c,
+		Scheme:               scheme,
+		EndpointSliceManager: endpointslice.NewManager(c, logr.Discard()),
+	}
+}
+
+func takeOverPolicy() *aviatorv1alpha1.AviatorPolicy {
+	return &aviatorv1alpha1.AviatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop", UID: "policy-uid"},
+		Spec: aviatorv1alpha1.AviatorPolicySpec{
+			TargetRef: aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindService, Name: "web"},
+			Routing:   &aviatorv1alpha1.RoutingSpec{Mode: aviatorv1alpha1.RoutingModeTakeOver},
+		},
+	}
+}
+
+func routingService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+	}
+}
+
+func routingSlice(name, managedBy string) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{
+			endpointslice.ManagedByLabel:   managedBy,
+			endpointslice.ServiceNameLabel: "web",
+		}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+}
+
+func getService(t *testing.T, r *AviatorPolicyReconciler) *corev1.Service {
+	t.Helper()
+	var svc corev1.Service
+	if err := r.Get(context.Background(), client.ObjectKey{Namespace: "shop", Name: "web"}, &svc); err != nil {
+		t.Fatal(err)
+	}
+	return &svc
+}
+
+func sliceNames(t *testing.T, r *AviatorPolicyReconciler) map[string]bool {
+	t.Helper()
+	var list discoveryv1.EndpointSliceList
+	if err := r.List(context.Background(), &list); err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]bool, len(list.Items))
+	for _, s := range list.Items {
+		names[s.Name] = true
+	}
+	return names
+}
+
+func TestTakeOverService(t *testing.T) {
+	ctx := context.Background()
+	r := newRoutingReconciler(
+		routingService(),
+		&corev1.Endpoints{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+		routingSlice("web-abcde", "endpointslice-controller.k8s.io"),
+		routingSlice("aviator-web", endpointslice.ManagedByValue),
+	)
+	policy := takeOverPolicy()
+
+	// Repeated reconciles must keep the original selector, not overwrite it
+	// with the now empty one.
+	for i := 0; i < 2; i++ {
+		if _, err := r.ensureRouting(ctx, policy, getService(t, r)); err != nil {
+			t.Fatalf("reconcile %d: %v", i, err)
+		}
+	}
+
+	svc := getService(t, r)
+	if len(svc.Spec.Selector) != 0 {
+		t.Errorf("selector = %v, want it removed", svc.Spec.Selector)
+	}
+	if got := svc.Annotations[originalSelectorAnnotation]; got != `{"app":"web"}` {
+		t.Errorf("original selector annotation = %q", got)
+	}
+	if got := svc.Annotations[routingOwnerAnnotation]; got != "web" {
+		t.Errorf("routing owner annotation = %q, want web", got)
+	}
+	err := r.Get(ctx, client.ObjectKey{Namespace: "shop", Name: "web"}, &corev1.Endpoints{})
+	if !errors.IsNotFound(err) {
+		t.Errorf("the Service's Endpoints should be deleted, got err %v", err)
+	}
+	if names := sliceNames(t, r); len(names) != 1 || !names["aviator-web"] {
+		t.Errorf("slices = %v, want only Aviator's", names)
+	}
+
+	other := takeOverPolicy()
+	other.Name = "other"
+	if _, err := r.ensureRouting(ctx, other, getService(t, r)); err == nil {
+		t.Error("a second policy took over an already taken-over Service")
+	}
+}
+
+func TestTakeOverService_NoSelector(t *testing.T) {
+	svc := routingService()
+	svc.Spec.Selector = nil
+	r := newRoutingReconciler(svc)
+	if _, err := r.ensureRouting(context.Background(), takeOverPolicy(), getService(t, r)); err == nil {
+		t.Error("expected an error taking over a Service without a selector")
+	}
+}
+
+func TestReleaseRouting_RestoresSelector(t *testing.T) {
+	ctx := context.Background()
+	svc := routingService()
+	r := newRoutingReconciler(svc, routingSlice("aviator-web", endpointslice.ManagedByValue))
+	policy := takeOverPolicy()
+	if _, err := r.ensureRouting(ctx, policy, getService(t, r)); err != nil {
+		t.Fatal(err)
+	}
+
+	now := metav1.Now()
+	policy.DeletionTimestamp = &now
+	requeue, err := r.releaseRouting(ctx, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored := getService(t, r)
+	if restored.Spec.Selector["app"] != "web" {
+		t.Errorf("selector = %v, want the original restored", restored.Spec.Selector)
+	}
+	if _, ok := restored.Annotations[originalSelectorAnnotation]; ok {
+		t.Error("original selector annotation was left behind")
+	}
+	if _, ok := restored.Annotations[routingOwnerAnnotation]; ok {
+		t.Error("routing owner annotation was left behind")
+	}
+	// Until kube-controller-manager recreates its slices, Aviator's stay.
+	if requeue != restoreWaitInterval || !sliceNames(t, r)["aviator-web"] {
+		t.Errorf("requeue = %s, slices = %v; want a wait with Aviator's slice kept", requeue, sliceNames(t, r))
+	}
+
+	if err := r.Create(ctx, routingSlice("web-abcde", "endpointslice-controller.k8s.io")); err != nil {
+		t.Fatal(err)
+	}
+	requeue, err = r.releaseRouting(ctx, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := sliceNames(t, r); requeue != 0 || len(names) != 1 || !names["web-abcde"] {
+		t.Errorf("requeue = %s, slices = %v; want Aviator's slice removed", requeue, names)
+	}
+}
+
+func TestReleaseRouting_WaitTimesOut(t *testing.T) {
+	ctx := context.Background()
+	r := newRoutingReconciler(routingService(), routingSlice("aviator-web", endpointslice.ManagedByValue))
+	policy := takeOverPolicy()
+	if _, err := r.ensureRouting(ctx, policy, getService(t, r)); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted := metav1.NewTime(time.Now().Add(-restoreWaitTimeout))
+	policy.DeletionTimestamp = &deleted
+	requeue, err := r.releaseRouting(ctx, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := sliceNames(t, r); requeue != 0 || len(names) != 0 {
+		t.Errorf("requeue = %s, slices = %v; want Aviator's slice removed after the timeout", requeue, names)
+	}
+}
+
+func TestRestoreService_MissingOriginalSelector(t *testing.T) {
+	ctx := context.Background()
+	// The annotation was removed by hand after the selector was put back.
+	svc := routingService()
+	svc.Annotations = map[string]string{routingOwnerAnnotation: "web"}
+	r := newRoutingReconciler(svc)
+
+	restored, err := r.restoreService(ctx, takeOverPolicy(), getService(t, r))
+	if err != nil || !restored {
+		t.Fatalf("restored = %v, err = %v; want the Service released", restored, err)
+	}
+	got := getService(t, r)
+	if got.Spec.Selector["app"] != "web" {
+		t.Errorf("selector = %v, want the current one kept", got.Spec.Selector)
+	}
+	if _, ok := got.Annotations[routingOwnerAnnotation]; ok {
+		t.Error("routing owner annotation was left behind")
+	}
+
+	// A Service the policy doesn't own is left alone.
+	restored, err = r.restoreService(ctx, takeOverPolicy(), routingService())
+	if err != nil || restored {
+		t.Errorf("restored = %v, err = %v; want an unowned Service untouched", restored, err)
+	}
+}
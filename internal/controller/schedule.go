@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/schedule"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// scheduleWindow parses a policy's schedule window.
+func scheduleWindow(w aviatorv1alpha1.ScheduleWindow) (schedule.Window, error) {
+	cron, err := schedule.ParseCron(w.Cron)
+	if err != nil {
+		return schedule.Window{}, fmt.Errorf("%s: %w", w.Name, err)
+	}
+	loc := time.UTC
+	if w.TimeZone != "" {
+		if loc, err = time.LoadLocation(w.TimeZone); err != nil {
+			return schedule.Window{}, fmt.Errorf("%s: %w", w.Name, err)
+		}
+	}
+	return schedule.Window{Cron: cron, Duration: w.Duration.Duration, Location: loc}, nil
+}
+
+// applySchedules overlays the overrides of every open schedule on the
+// policy's spec, in order, and reports them in status. Only the in-memory
+// spec changes; status updates leave the stored spec alone. Schedules that
+// cannot be parsed are skipped and reported in the Scheduled condition.
+func (r *AviatorPolicyReconciler) applySchedules(policy *aviatorv1alpha1.AviatorPolicy, now time.Time) {
+	var active, invalid []string
+	for _, o := range policy.Spec.Schedules {
+		w, err := scheduleWindow(o.ScheduleWindow)
+		if err != nil {
+			invalid = append(invalid, err.Error())
+			continue
+		}
+		if _, open := w.ActiveAt(now); !open {
+			continue
+		}
+		active = append(active, o.Name)
+		if o.LatencyThreshold != nil {
+			policy.Spec.LatencyThreshold = *o.LatencyThreshold
+		}
+		if o.Selection != nil {
+			policy.Spec.Selection = *o.Selection.DeepCopy()
+		}
+		if o.CircuitBreaker != nil {
+			policy.Spec.CircuitBreaker = o.CircuitBreaker.DeepCopy()
+		}
+		if o.Dampening != nil {
+			policy.Spec.Dampening = o.Dampening.DeepCopy()
+		}
+	}
+
+	if !slices.Equal(active, policy.Status.ActiveSchedules) {
+		r.eventf(policy, corev1.EventTypeNormal, "SchedulesChanged", "Schedule overrides in effect: %s",
+			formatNames(active))
+	}
+	policy.Status.ActiveSchedules = active
+
+	switch {
+	case len(invalid) > 0:
+		r.setCondition(policy, "Scheduled", metav1.ConditionFalse, "InvalidSchedule", strings.Join(invalid, "; "))
+	case len(policy.Spec.Schedules) == 0:
+		meta.RemoveStatusCondition(&policy.Status.Conditions, "Scheduled")
+	case len(active) > 0:
+		r.setCondition(policy, "Scheduled", metav1.ConditionTrue, "OverridesActive",
+			fmt.Sprintf("Overrides of %s in effect", formatNames(active)))
+	default:
+		r.setCondition(policy, "Scheduled", metav1.ConditionFalse, "NoScheduleOpen", "No schedule is open")
+	}
+}
+
+// checkFreeze reports whether a freeze window is open and, if so, when the
+// last one to close closes. It keeps the Frozen condition and
+// status.frozenUntil current and emits an Event when a freeze starts or ends.
+// Freezes that cannot be parsed are ignored and reported in the condition.
+func (r *AviatorPolicyReconciler) checkFreeze(policy *aviatorv1alpha1.AviatorPolicy, now time.Time) (time.Time, bool) {
+	var until time.Time
+	var names, invalid []string
+	for _, f := range policy.Spec.Freezes {
+		w, err := scheduleWindow(f)
+		if err != nil {
+			invalid = append(invalid, err.Error())
+			continue
+		}
+		if end, open := w.ActiveAt(now); open {
+			names = append(names, f.Name)
+			if end.After(until) {
+				until = end
+			}
+		}
+	}
+
+	wasFrozen := meta.IsStatusConditionTrue(policy.Status.Conditions, "Frozen")
+	switch {
+	case len(names) > 0:
+		if !wasFrozen {
+			r.eventf(policy, corev1.EventTypeNormal, "FreezeStarted",
+				"Freeze %s started; EndpointSlices are left unchanged until %s",
+				formatNames(names), until.UTC().Format(time.RFC3339))
+		}
+		frozenUntil := metav1.NewTime(until)
+		policy.Status.FrozenUntil = &frozenUntil
+		r.setCondition(policy, "Frozen", metav1.ConditionTrue, "FreezeWindowOpen",
+			fmt.Sprintf("Freeze %s open until %s", formatNames(names), until.UTC().Format(time.RFC3339)))
+		return until, true
+	case wasFrozen:
+		r.eventf(policy, corev1.EventTypeNormal, "FreezeEnded", "Freeze ended; EndpointSlice updates resume")
+	}
+	policy.Status.FrozenUntil = nil
+	switch {
+	case len(invalid) > 0:
+		r.setCondition(policy, "Frozen", metav1.ConditionFalse, "InvalidFreeze", strings.Join(invalid, "; "))
+	case len(policy.Spec.Freezes) == 0:
+		meta.RemoveStatusCondition(&policy.Status.Conditions, "Frozen")
+	default:
+		r.setCondition(policy, "Frozen", metav1.ConditionFalse, "NoFreezeOpen", "No freeze window is open")
+	}
+	return time.Time{}, false
+}
+
+// formatNames formats schedule names for a message.
+func formatNames(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestApplySchedules(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{Recorder: recorder}
+	topN := int32(2)
+	window := func(name, cron string) aviatorv1alpha1.ScheduleWindow {
+		return aviatorv1alpha1.ScheduleWindow{Name: name, Cron: cron, Duration: metav1.Duration{Duration: 8 * time.Hour}}
+	}
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		LatencyThreshold: metav1.Duration{Duration: 100 * time.Millisecond},
+		Selection:        aviatorv1alpha1.SelectionPolicy{Mode: aviatorv1alpha1.SelectionModePercentage},
+		Schedules: []aviatorv1alpha1.ScheduledOverride{
+			{
+				ScheduleWindow:   window("day", "0 8 * * *"),
+				LatencyThreshold: &metav1.Duration{Duration: 50 * time.Millisecond},
+			},
+			{
+				ScheduleWindow: window("peak", "0 9 * * *"),
+				Selection:      &aviatorv1alpha1.SelectionPolicy{Mode: aviatorv1alpha1.SelectionModeTopN, TopN: &topN},
+			},
+			{
+				ScheduleWindow:   window("night", "0 22 * * *"),
+				LatencyThreshold: &metav1.Duration{Duration: time.Second},
+			},
+		},
+	}}
+
+	r.applySchedules(policy, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC))
+	if got := policy.Status.ActiveSchedules; len(got) != 2 || got[0] != "day" || got[1] != "peak" {
+		t.Errorf("active schedules = %v, want [day peak]", got)
+	}
+	if policy.Spec.LatencyThreshold.Duration != 50*time.Millisecond || policy.Spec.Selection.Mode != aviatorv1alpha1.SelectionModeTopN {
+		t.Errorf("overrides not applied: threshold %s, mode %s", policy.Spec.LatencyThreshold.Duration, policy.Spec.Selection.Mode)
+	}
+	if !meta.IsStatusConditionTrue(policy.Status.Conditions, "Scheduled") {
+		t.Error("Scheduled should be True while overrides are in effect")
+	}
+	if len(recorder.Events) != 1 {
+		t.Errorf("got %d events, want 1", len(recorder.Events))
+	}
+
+	policy.Spec.Schedules[0].Cron = "every day"
+	r.applySchedules(policy, time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC))
+	if cond := meta.FindStatusCondition(policy.Status.Conditions, "Scheduled"); cond == nil || cond.Reason != "InvalidSchedule" {
+		t.Errorf("Scheduled condition = %+v, want InvalidSchedule", cond)
+	}
+}
+
+func TestCheckFreeze(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &AviatorPolicyReconciler{Recorder: recorder}
+	policy := &aviatorv1alpha1.AviatorPolicy{Spec: aviatorv1alpha1.AviatorPolicySpec{
+		Freezes: []aviatorv1alpha1.ScheduleWindow{
+			{Name: "deploy", Cron: "0 18 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			{Name: "maintenance", Cron: "30 18 * * *", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		},
+	}}
+	at := func(hour, minute int) time.Time { return time.Date(2025, 6, 2, hour, minute, 0, 0, time.UTC) }
+
+	until, frozen := r.checkFreeze(policy, at(19, 0))
+	if !frozen || !until.Equal(at(20, 30)) {
+		t.Errorf("got until %s, frozen %v; want frozen until 20:30", until, frozen)
+	}
+	if policy.Status.FrozenUntil == nil || !meta.IsStatusConditionTrue(policy.Status.Conditions, "Frozen") {
+		t.Errorf("freeze not reported: %+v", policy.Status)
+	}
+	r.checkFreeze(policy, at(19, 5))
+
+	if _, frozen := r.checkFreeze(policy, at(21, 0)); frozen {
+		t.Error("freeze should have ended")
+	}
+	if policy.Status.FrozenUntil != nil || !meta.IsStatusConditionFalse(policy.Status.Conditions, "Frozen") {
+		t.Errorf("end of freeze not reported: %+v", policy.Status)
+	}
+	// FreezeStarted and FreezeEnded.
+	if len(recorder.Events) != 2 {
+		t.Errorf("got %d events, want 2", len(recorder.Events))
+	}
+}
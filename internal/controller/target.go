@@ -0,0 +1,165 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// rolloutGVK identifies Argo Rollouts, which are read unstructured so the
+// controller does not depend on the Argo API module.
+var rolloutGVK = schema.GroupVersionKind{Group: "argoproj.io", Version: "v1alpha1", Kind: "Rollout"}
+
+// targetsService reports whether the policy targets an existing Service, as
+// opposed to a workload whose fronting Service the controller owns.
+func targetsService(policy *aviatorv1alpha1.AviatorPolicy) bool {
+	kind := policy.Spec.TargetRef.Kind
+	return kind == "" || kind == aviatorv1alpha1.TargetKindService
+}
+
+// targetSelector resolves a workload or Pod target to the selector of its
+// pods. The lookup error is returned unwrapped so callers can check for
+// NotFound.
+func (r *AviatorPolicyReconciler) targetSelector(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+) (labels.Selector, error) {
+	ref := policy.Spec.TargetRef
+	key := types.NamespacedName{Name: ref.Name, Namespace: policy.Namespace}
+
+	var selector *metav1.LabelSelector
+	switch ref.Kind {
+	case aviatorv1alpha1.TargetKindDeployment:
+		var deployment appsv1.Deployment
+		if err := r.Get(ctx, key, &deployment); err != nil {
+			return nil, err
+		}
+		selector = deployment.Spec.Selector
+	case aviatorv1alpha1.TargetKindStatefulSet:
+		var statefulSet appsv1.StatefulSet
+		if err := r.Get(ctx, key, &statefulSet); err != nil {
+			return nil, err
+		}
+		selector = statefulSet.Spec.Selector
+	case aviatorv1alpha1.TargetKindRollout:
+		rollout := &unstructured.Unstructured{}
+		rollout.SetGroupVersionKind(rolloutGVK)
+		if err := r.Get(ctx, key, rollout); err != nil {
+			return nil, err
+		}
+		raw, found, err := unstructured.NestedMap(rollout.Object, "spec", "selector")
+		if err != nil || !found {
+			return nil, fmt.Errorf("rollout %s has no selector", ref.Name)
+		}
+		selector = &metav1.LabelSelector{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, selector); err != nil {
+			return nil, fmt.Errorf("parsing rollout selector: %w", err)
+		}
+	case aviatorv1alpha1.TargetKindPod:
+		selector = ref.Selector
+	default:
+		return nil, fmt.Errorf("unsupported target kind %q", ref.Kind)
+	}
+
+	if selector == nil {
+		return nil, fmt.Errorf("%s %s has no pod selector", ref.Kind, ref.Name)
+	}
+	return metav1.LabelSelectorAsSelector(selector)
+}
+
+// frontingService builds, without persisting it, the selectorless Service
+// that fronts a workload target. Its ports are the union of the pods'
+// container ports; named ports target the port name, so pods that number
+// them differently still resolve. A Service can list each port and protocol
+// only once, so named ports take precedence over unnamed ones with the same
+// number.
+func frontingService(policy *aviatorv1alpha1.AviatorPolicy, pods []corev1.Pod) *corev1.Service {
+	type portKey struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+	seenName := make(map[string]bool)
+	seenPort := make(map[portKey]bool)
+	var ports []corev1.ServicePort
+	collect := func(named bool) {
+		for _, pod := range pods {
+			for _, container := range pod.Spec.Containers {
+				for _, cp := range container.Ports {
+					if (cp.Name != "") != named {
+						continue
+					}
+					protocol := cp.Protocol
+					if protocol == "" {
+						protocol = corev1.ProtocolTCP
+					}
+					port := corev1.ServicePort{
+						Name:       cp.Name,
+						Protocol:   protocol,
+						Port:       cp.ContainerPort,
+						TargetPort: intstr.FromInt32(cp.ContainerPort),
+					}
+					if named {
+						port.TargetPort = intstr.FromString(cp.Name)
+					} else {
+						port.Name = fmt.Sprintf("%s-%d", strings.ToLower(string(protocol)), cp.ContainerPort)
+					}
+					key := portKey{port.Port, protocol}
+					if seenName[port.Name] || seenPort[key] {
+						continue
+					}
+					seenName[port.Name] = true
+					seenPort[key] = true
+					ports = append(ports, port)
+				}
+			}
+		}
+	}
+	collect(true)
+	collect(false)
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Port != ports[j].Port {
+			return ports[i].Port < ports[j].Port
+		}
+		return ports[i].Name < ports[j].Name
+	})
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      derivedServiceName(policy),
+			Namespace: policy.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			Type:  corev1.ServiceTypeClusterIP,
+			Ports: ports,
+		},
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func rollout(name string, selector map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	u.SetGroupVersionKind(rolloutGVK)
+	u.SetName(name)
+	u.SetNamespace("shop")
+	if selector != nil {
+		_ = unstructured.SetNestedMap(u.Object, selector, "spec", "selector")
+	}
+	return u
+}
+
+func TestTargetSelector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = aviatorv1alpha1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(rolloutGVK, &unstructured.Unstructured{})
+
+	web := map[string]string{"app": "web"}
+	objs := []client.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+			Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: web}},
+		},
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "bare", Namespace: "shop"}},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "shop"},
+			Spec: appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "app", Operator: metav1.LabelSelectorOpIn, Values: []string{"db", "db-replica"}},
+				},
+			}},
+		},
+		rollout("canary", map[string]interface{}{"matchLabels": map[string]interface{}{"app": "web"}}),
+		rollout("blue", map[string]interface{}{"matchExpressions": []interface{}{
+			map[string]interface{}{"key": "track", "operator": "NotIn", "values": []interface{}{"preview"}},
+		}}),
+		rollout("bare", nil),
+	}
+	r := &AviatorPolicyReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()}
+
+	tests := []struct {
+		name     string
+		ref      aviatorv1alpha1.TargetRef
+		match    labels.Set
+		noMatch  labels.Set
+		notFound bool
+		wantErr  bool
+	}{
+		{
+			name:    "deployment",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindDeployment, Name: "web"},
+			match:   labels.Set{"app": "web", "pod-template-hash": "abc"},
+			noMatch: labels.Set{"app": "db"},
+		},
+		{
+			name:     "missing deployment",
+			ref:      aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindDeployment, Name: "gone"},
+			notFound: true,
+		},
+		{
+			name:    "deployment without a selector",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindDeployment, Name: "bare"},
+			wantErr: true,
+		},
+		{
+			name:    "statefulset with match expressions",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindStatefulSet, Name: "db"},
+			match:   labels.Set{"app": "db-replica"},
+			noMatch: labels.Set{"app": "web"},
+		},
+		{
+			name:     "missing statefulset",
+			ref:      aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindStatefulSet, Name: "gone"},
+			notFound: true,
+		},
+		{
+			name:    "rollout",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindRollout, Name: "canary"},
+			match:   labels.Set{"app": "web"},
+			noMatch: labels.Set{"app": "db"},
+		},
+		{
+			name:    "rollout with match expressions",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindRollout, Name: "blue"},
+			match:   labels.Set{"track": "stable"},
+			noMatch: labels.Set{"track": "preview"},
+		},
+		{
+			name:     "missing rollout",
+			ref:      aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindRollout, Name: "gone"},
+			notFound: true,
+		},
+		{
+			name:    "rollout without a selector",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindRollout, Name: "bare"},
+			wantErr: true,
+		},
+		{
+			name: "pod selector",
+			ref: aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindPod, Name: "batch", Selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "job", Operator: metav1.LabelSelectorOpExists}},
+			}},
+			match:   labels.Set{"job": "nightly"},
+			noMatch: labels.Set{"app": "web"},
+		},
+		{
+			name:    "pod without a selector",
+			ref:     aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindPod, Name: "batch"},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported kind",
+			ref:     aviatorv1alpha1.TargetRef{Kind: "DaemonSet", Name: "agent"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &aviatorv1alpha1.AviatorPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "shop"},
+				Spec:       aviatorv1alpha1.AviatorPolicySpec{TargetRef: tt.ref},
+			}
+			selector, err := r.targetSelector(context.Background(), policy)
+			switch {
+			case tt.notFound:
+				if !errors.IsNotFound(err) {
+					t.Fatalf("err = %v, want NotFound", err)
+				}
+				return
+			case tt.wantErr:
+				if err == nil {
+					t.Fatalf("got selector %v, want an error", selector)
+				}
+				return
+			case err != nil:
+				t.Fatal(err)
+			}
+			if !selector.Matches(tt.match) {
+				t.Errorf("selector %v does not match %v", selector, tt.match)
+			}
+			if selector.Matches(tt.noMatch) {
+				t.Errorf("selector %v matches %v", selector, tt.noMatch)
+			}
+		})
+	}
+}
+
+func TestFrontingService_DeduplicatesPorts(t *testing.T) {
+	policy := &aviatorv1alpha1.AviatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "shop"},
+		Spec: aviatorv1alpha1.AviatorPolicySpec{TargetRef: aviatorv1alpha1.TargetRef{
+			Kind: aviatorv1alpha1.TargetKindDeployment, Name: "web",
+		}},
+	}
+	pod := func(ports ...corev1.ContainerPort) corev1.Pod {
+		return corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Ports: ports}}}}
+	}
+	pods := []corev1.Pod{
+		// An older pod template exposed 8080 unnamed; the newer one names it.
+		pod(corev1.ContainerPort{ContainerPort: 8080}, corev1.ContainerPort{ContainerPort: 9090}),
+		pod(corev1.ContainerPort{Name: "http", ContainerPort: 8080}),
+		pod(corev1.ContainerPort{Name: "http", ContainerPort: 8081}),
+		pod(corev1.ContainerPort{ContainerPort: 8080, Protocol: corev1.ProtocolUDP}),
+	}
+
+	svc := frontingService(policy, pods)
+	want := []corev1.ServicePort{
+		{Name: "http", Protocol: corev1.ProtocolTCP, Port: 8080, TargetPort: intstr.FromString("http")},
+		{Name: "udp-8080", Protocol: corev1.ProtocolUDP, Port: 8080, TargetPort: intstr.FromInt32(8080)},
+		{Name: "tcp-9090", Protocol: corev1.ProtocolTCP, Port: 9090, TargetPort: intstr.FromInt32(9090)},
+	}
+	if !equality.Semantic.DeepEqual(svc.Spec.Ports, want) {
+		t.Errorf("ports = %+v, want %+v", svc.Spec.Ports, want)
+	}
+	if svc.Name != "web-fast" || svc.Namespace != "shop" {
+		t.Errorf("service = %s/%s, want shop/web-fast", svc.Namespace, svc.Name)
+	}
+}
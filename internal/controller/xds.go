@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/endpointslice"
+	"aviator/internal/latency"
+	"aviator/internal/xds"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// publishXDS publishes one EDS ClusterLoadAssignment per Service port. Every
+// ranked pod is listed: selected pods are HEALTHY with a weight inversely
+// proportional to their P99, pods ejected by the circuit breaker UNHEALTHY,
+// other excluded pods DEGRADED (used by Envoy only when too few are
+// healthy), and terminating or draining pods DRAINING.
+func (r *AviatorPolicyReconciler) publishXDS(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	service *corev1.Service,
+	candidates []latency.PodRanking,
+	selected []latency.PodRanking,
+	terminating []corev1.Pod,
+	draining []endpointslice.PodEndpoint,
+	podIPMap map[string]corev1.Pod,
+	nodeZones map[string]string,
+	breaker *circuitbreaker.Breaker,
+) error {
+	if r.XDS == nil {
+		return nil
+	}
+
+	isDraining := make(map[string]bool, len(draining))
+	for _, d := range draining {
+		isDraining[d.PodIP] = true
+	}
+	isSelected := make(map[string]bool, len(selected))
+	var fastest time.Duration
+	for _, s := range selected {
+		isSelected[s.PodIP] = true
+		if p99 := s.Stats.P99; p99 > 0 && (fastest == 0 || p99 < fastest) {
+			fastest = p99
+		}
+	}
+
+	type member struct {
+		pod    *corev1.Pod
+		zone   string
+		weight uint32
+		health corev3.HealthStatus
+	}
+	var members []member
+	seen := make(map[string]bool, len(candidates))
+	for _, c := range appendMissing(append([]latency.PodRanking(nil), candidates...), selected) {
+		pod, ok := podIPMap[c.PodIP]
+		if !ok || seen[c.PodIP] {
+			continue
+		}
+		seen[c.PodIP] = true
+		m := member{pod: &pod, zone: c.Zone, weight: 1, health: corev3.HealthStatus_DEGRADED}
+		switch {
+		case isSelected[c.PodIP]:
+			m.health = corev3.HealthStatus_HEALTHY
+			m.weight = xds.LatencyWeight(c.Stats.P99, fastest)
+		case isDraining[c.PodIP]:
+			m.health = corev3.HealthStatus_DRAINING
+		case breaker != nil && breaker.IsEjected(c.PodIP):
+			m.health = corev3.HealthStatus_UNHEALTHY
+		}
+		members = append(members, m)
+	}
+	for i := range terminating {
+		pod := &terminating[i]
+		members = append(members, member{
+			pod:    pod,
+			zone:   nodeZones[pod.Spec.NodeName],
+			weight: 1,
+			health: corev3.HealthStatus_DRAINING,
+		})
+	}
+
+	assignments := make([]*endpointv3.ClusterLoadAssignment, 0, len(service.Spec.Ports))
+	for _, sp := range service.Spec.Ports {
+		var endpoints []xds.Endpoint
+		for _, m := range members {
+			for _, p := range endpointslice.ResolvePorts(service, m.pod) {
+				if *p.Name != sp.Name {
+					continue
+				}
+				endpoints = append(endpoints, xds.Endpoint{
+					Address: m.pod.Status.PodIP,
+					Port:    uint32(*p.Port),
+					Zone:    m.zone,
+					Weight:  m.weight,
+					Health:  m.health,
+				})
+			}
+		}
+		cluster := xds.ClusterName(service.Namespace, service.Name, sp.Port)
+		assignments = append(assignments, xds.LoadAssignment(cluster, endpoints))
+	}
+	return r.XDS.Update(ctx, xdsKey(policy), assignments)
+}
+
+// xdsKey identifies a policy's assignments in the xDS server.
+func xdsKey(policy *aviatorv1alpha1.AviatorPolicy) string {
+	return types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}.String()
+}
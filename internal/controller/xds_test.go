@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/circuitbreaker"
+	"aviator/internal/endpointslice"
+	"aviator/internal/latency"
+	"aviator/internal/xds"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func xdsPod(name, ip string, ports ...corev1.ContainerPort) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Ports: ports}}},
+		Status:     corev1.PodStatus{PodIP: ip},
+	}
+}
+
+type xdsMember struct {
+	port   uint32
+	weight uint32
+	health corev3.HealthStatus
+}
+
+func TestPublishXDS(t *testing.T) {
+	r := &AviatorPolicyReconciler{XDS: xds.NewServer("", logr.Discard())}
+	policy := &aviatorv1alpha1.AviatorPolicy{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{
+			{Name: "http", Port: 80, TargetPort: intstr.FromString("http")},
+			{Name: "metrics", Port: 9090},
+		}},
+	}
+
+	httpPort := corev1.ContainerPort{Name: "http", ContainerPort: 8080}
+	pods := map[string]corev1.Pod{
+		"10.0.0.1": xdsPod("fast", "10.0.0.1", httpPort),
+		"10.0.0.2": xdsPod("slow", "10.0.0.2", httpPort),
+		"10.0.0.3": xdsPod("ejected", "10.0.0.3", httpPort),
+		"10.0.0.4": xdsPod("draining", "10.0.0.4", httpPort),
+		"10.0.0.5": xdsPod("excluded", "10.0.0.5", httpPort),
+		// No http container port, so it is only listed for metrics.
+		"10.0.0.6": xdsPod("metrics-only", "10.0.0.6"),
+	}
+	rank := func(ip string, p99 time.Duration) latency.PodRanking {
+		return latency.PodRanking{PodName: pods[ip].Name, PodIP: ip, Stats: latency.Stats{P99: p99}, Zone: "a"}
+	}
+	candidates := []latency.PodRanking{
+		rank("10.0.0.1", 10*time.Millisecond),
+		rank("10.0.0.2", 40*time.Millisecond),
+		rank("10.0.0.3", 90*time.Millisecond),
+		rank("10.0.0.4", 50*time.Millisecond),
+		rank("10.0.0.5", 60*time.Millisecond),
+	}
+	selected := []latency.PodRanking{candidates[0], candidates[1], rank("10.0.0.6", 0)}
+	terminating := []corev1.Pod{xdsPod("terminating", "10.0.0.7", httpPort)}
+	terminating[0].Spec.NodeName = "node-b"
+	draining := []endpointslice.PodEndpoint{{PodName: "draining", PodIP: "10.0.0.4"}}
+	breaker := circuitbreaker.New(circuitbreaker.Config{RecoveryInterval: time.Minute})
+	breaker.ForceEject("10.0.0.3")
+
+	err := r.publishXDS(context.Background(), policy, service, candidates, selected, terminating, draining,
+		pods, map[string]string{"node-b": "b"}, breaker)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assignments := r.XDS.Assignments(xdsKey(policy))
+	if len(assignments) != 2 {
+		t.Fatalf("got %d assignments, want one per Service port", len(assignments))
+	}
+	got := make(map[string]map[string]xdsMember)
+	for _, cla := range assignments {
+		members := make(map[string]xdsMember)
+		for _, locality := range cla.Endpoints {
+			for _, ep := range locality.LbEndpoints {
+				addr := ep.GetEndpoint().GetAddress().GetSocketAddress()
+				members[addr.GetAddress()] = xdsMember{
+					port:   addr.GetPortValue(),
+					weight: ep.GetLoadBalancingWeight().GetValue(),
+					health: ep.HealthStatus,
+				}
+			}
+		}
+		got[cla.ClusterName] = members
+	}
+
+	healthy, drain := corev3.HealthStatus_HEALTHY, corev3.HealthStatus_DRAINING
+	wantHTTP := map[string]xdsMember{
+		"10.0.0.1": {8080, xds.MaxWeight, healthy},
+		"10.0.0.2": {8080, xds.MaxWeight / 4, healthy},
+		"10.0.0.3": {8080, 1, corev3.HealthStatus_UNHEALTHY},
+		"10.0.0.4": {8080, 1, drain},
+		"10.0.0.5": {8080, 1, corev3.HealthStatus_DEGRADED},
+		"10.0.0.7": {8080, 1, drain},
+	}
+	checkCluster(t, got[xds.ClusterName("shop", "web", 80)], wantHTTP)
+
+	wantMetrics := map[string]xdsMember{"10.0.0.6": {9090, xds.MaxWeight, healthy}}
+	for ip, m := range wantHTTP {
+		m.port = 9090
+		wantMetrics[ip] = m
+	}
+	checkCluster(t, got[xds.ClusterName("shop", "web", 9090)], wantMetrics)
+}
+
+func checkCluster(t *testing.T, got, want map[string]xdsMember) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("got %d endpoints, want %d: %v", len(got), len(want), got)
+	}
+	for ip, w := range want {
+		if g, ok := got[ip]; !ok || g != w {
+			t.Errorf("endpoint %s = %+v, want %+v", ip, g, w)
+		}
+	}
+}
+
+func TestPublishXDS_Disabled(t *testing.T) {
+	r := &AviatorPolicyReconciler{}
+	if err := r.publishXDS(context.Background(), &aviatorv1alpha1.AviatorPolicy{}, &corev1.Service{},
+		nil, nil, nil, nil, nil, nil, nil); err != nil {
+		t.Errorf("publishing without an xDS server: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package endpointslice
+
+import (
+	"sort"
+	"time"
+)
+
+// DrainingPod is a pod kept in the slice after leaving the selected set.
+type DrainingPod struct {
+	Endpoint PodEndpoint
+	// Since is when the pod left the selected set.
+	Since time.Time
+	// Until is when the pod is dropped from the slice.
+	Until time.Time
+}
+
+// DrainTracker keeps pods that leave the selected set in the slice for a
+// drain period, marked not serving and terminating. Proxies then stop
+// sending new connections to them without cutting existing ones.
+type DrainTracker struct {
+	active   map[string]PodEndpoint
+	draining map[string]DrainingPod
+}
+
+// NewDrainTracker creates an empty DrainTracker.
+func NewDrainTracker() *DrainTracker {
+	return &DrainTracker{
+		active:   make(map[string]PodEndpoint),
+		draining: make(map[string]DrainingPod),
+	}
+}
+
+// Update records the endpoints about to be written and returns the pods to
+// keep draining, sorted by IP. Endpoints already terminating are not tracked,
+// as they drain on their own. A pod stops draining when it is selected
+// again, when its drain period ends, or when exists reports it gone.
+func (d *DrainTracker) Update(
+	endpoints []PodEndpoint,
+	exists func(podIP string) bool,
+	period time.Duration,
+	now time.Time,
+) []DrainingPod {
+	active := make(map[string]PodEndpoint, len(endpoints))
+	listed := make(map[string]bool, len(endpoints))
+	for _, ep := range endpoints {
+		listed[ep.PodIP] = true
+		if !ep.Terminating {
+			active[ep.PodIP] = ep
+		}
+	}
+
+	for ip, ep := range d.active {
+		if _, ok := active[ip]; !ok {
+			if _, draining := d.draining[ip]; !draining {
+				d.draining[ip] = DrainingPod{Endpoint: ep, Since: now, Until: now.Add(period)}
+			}
+		}
+	}
+	d.active = active
+
+	pods := make([]DrainingPod, 0, len(d.draining))
+	for ip, pod := range d.draining {
+		if listed[ip] || !now.Before(pod.Until) || !exists(ip) {
+			delete(d.draining, ip)
+			continue
+		}
+		pod.Endpoint.Ready = false
+		pod.Endpoint.Serving = false
+		pod.Endpoint.Terminating = true
+		pods = append(pods, pod)
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Endpoint.PodIP < pods[j].Endpoint.PodIP })
+	return pods
+}
+
+// Pending reports whether any pod is draining.
+func (d *DrainTracker) Pending() bool {
+	return len(d.draining) > 0
+}
+
+// NextExpiry returns when the first drain period ends, if any pod is
+// draining.
+func (d *DrainTracker) NextExpiry() (time.Time, bool) {
+	var next time.Time
+	for _, pod := range d.draining {
+		if next.IsZero() || pod.Until.Before(next) {
+			next = pod.Until
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// Active returns the endpoints last passed to Update that were not
+// terminating, sorted by IP.
+func (d *DrainTracker) Active() []PodEndpoint {
+	endpoints := make([]PodEndpoint, 0, len(d.active))
+	for _, ep := range d.active {
+		endpoints = append(endpoints, ep)
+	}
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].PodIP < endpoints[j].PodIP })
+	return endpoints
+}
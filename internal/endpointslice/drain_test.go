@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package endpointslice
+
+import (
+	"testing"
+	"time"
+)
+
+func allExist(string) bool { return true }
+
+func TestDrainTracker_DrainsRemovedPods(t *testing.T) {
+	d := NewDrainTracker()
+	now := time.Now()
+	a := PodEndpoint{PodName: "pod-a", PodIP: "10.0.0.1", Ready: true, Serving: true}
+	b := PodEndpoint{PodName: "pod-b", PodIP: "10.0.0.2", Ready: true, Serving: true}
+
+	if drained := d.Update([]PodEndpoint{a, b}, allExist, 30*time.Second, now); len(drained) != 0 {
+		t.Fatalf("expected nothing draining, got %d", len(drained))
+	}
+
+	drained := d.Update([]PodEndpoint{a}, allExist, 30*time.Second, now.Add(5*time.Second))
+	if len(drained) != 1 || drained[0].Endpoint.PodName != "pod-b" {
+		t.Fatalf("expected pod-b draining, got %+v", drained)
+	}
+	ep := drained[0].Endpoint
+	if ep.Ready || ep.Serving || !ep.Terminating {
+		t.Errorf("draining pod should be not ready, not serving and terminating, got %+v", ep)
+	}
+	if !drained[0].Until.Equal(now.Add(35 * time.Second)) {
+		t.Errorf("unexpected drain deadline %v", drained[0].Until)
+	}
+
+	if drained := d.Update([]PodEndpoint{a}, allExist, 30*time.Second, now.Add(20*time.Second)); len(drained) != 1 {
+		t.Errorf("expected pod-b still draining, got %d", len(drained))
+	}
+	if drained := d.Update([]PodEndpoint{a}, allExist, 30*time.Second, now.Add(35*time.Second)); len(drained) != 0 || d.Pending() {
+		t.Errorf("expected drain to end after the period, got %d", len(drained))
+	}
+}
+
+func TestDrainTracker_ReselectedPodStopsDraining(t *testing.T) {
+	d := NewDrainTracker()
+	now := time.Now()
+	a := PodEndpoint{PodName: "pod-a", PodIP: "10.0.0.1", Ready: true, Serving: true}
+
+	d.Update([]PodEndpoint{a}, allExist, time.Minute, now)
+	d.Update(nil, allExist, time.Minute, now.Add(time.Second))
+	if drained := d.Update([]PodEndpoint{a}, allExist, time.Minute, now.Add(2*time.Second)); len(drained) != 0 {
+		t.Errorf("re-selected pod should not drain, got %+v", drained)
+	}
+}
+
+func TestDrainTracker_DeletedPodStopsDraining(t *testing.T) {
+	d := NewDrainTracker()
+	now := time.Now()
+	a := PodEndpoint{PodName: "pod-a", PodIP: "10.0.0.1", Ready: true, Serving: true}
+
+	d.Update([]PodEndpoint{a}, allExist, time.Minute, now)
+	gone := func(string) bool { return false }
+	if drained := d.Update(nil, gone, time.Minute, now.Add(time.Second)); len(drained) != 0 {
+		t.Errorf("deleted pod should not drain, got %+v", drained)
+	}
+}
+
+func TestDrainTracker_NextExpiry(t *testing.T) {
+	d := NewDrainTracker()
+	now := time.Now()
+	a := PodEndpoint{PodName: "pod-a", PodIP: "10.0.0.1"}
+	b := PodEndpoint{PodName: "pod-b", PodIP: "10.0.0.2"}
+
+	d.Update([]PodEndpoint{a, b}, allExist, 10*time.Second, now)
+	if _, ok := d.NextExpiry(); ok {
+		t.Error("NextExpiry reported a drain with nothing draining")
+	}
+	d.Update([]PodEndpoint{a}, allExist, 10*time.Second, now.Add(time.Second))
+	d.Update(nil, allExist, 10*time.Second, now.Add(3*time.Second))
+	if next, ok := d.NextExpiry(); !ok || !next.Equal(now.Add(11*time.Second)) {
+		t.Errorf("NextExpiry = %v, %v; want pod-b's deadline", next, ok)
+	}
+	if active := d.Active(); len(active) != 0 {
+		t.Errorf("Active = %+v, want none", active)
+	}
+}
@@ -12,14 +12,20 @@ package endpointslice
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	aviatorv1alpha1 "aviator/api/v1alpha1"
@@ -36,12 +42,27 @@ const (
 	PolicyNameLabel = "aviator.io/policy-name"
 	// AviatorManagedAnnotation marks a Service as managed by Aviator.
 	AviatorManagedAnnotation = "aviator.io/managed"
+	// ContentHashAnnotation records the hash of the content Aviator last
+	// applied to a slice.
+	ContentHashAnnotation = "aviator.io/content-hash"
 )
 
+// WriteStats counts the outcome of the EndpointSlice writes in one Reconcile.
+type WriteStats struct {
+	Applied    int
+	Skipped    int
+	Conflicted int
+}
+
 // Manager handles creation and updates of Aviator-owned EndpointSlices.
 type Manager struct {
 	client client.Client
 	log    logr.Logger
+
+	// MaxEndpointsPerSlice caps the endpoints in each slice. Zero means
+	// DefaultMaxEndpointsPerSlice; values above MaxEndpointsPerSliceLimit are
+	// capped.
+	MaxEndpointsPerSlice int
 }
 
 // NewManager creates a new EndpointSlice manager.
@@ -54,80 +75,418 @@ func NewManager(c client.Client, log logr.Logger) *Manager {
 
 // PodEndpoint represents a pod that should be included in the EndpointSlice.
 type PodEndpoint struct {
-	PodName string
-	PodIP   string
-	NodeName string
-	Ready   bool
+	PodName     string
+	PodIP       string
+	NodeName    string
+	Zone        string
+	Ready       bool
+	Serving     bool
+	Terminating bool
+	// Ports are the pod's resolved ports (see ResolvePorts). If nil, the
+	// Service's numeric target ports are used.
+	Ports []discoveryv1.EndpointPort
+}
+
+// PodConditions returns the EndpointSlice conditions for a pod, following the
+// built-in EndpointSlice controller: serving mirrors the pod's Ready
+// condition (which already accounts for readiness gates), ready additionally
+// requires that the pod is not terminating, and publishNotReadyAddresses
+// forces ready, but not serving, to true.
+func PodConditions(pod *corev1.Pod, publishNotReady bool) (ready, serving, terminating bool) {
+	terminating = pod.DeletionTimestamp != nil
+	serving = IsPodReady(pod)
+	ready = publishNotReady || (serving && !terminating)
+	return ready, serving, terminating
+}
+
+// IsPodReady returns true if the pod's Ready condition is true.
+func IsPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
-// Reconcile creates or updates an Aviator-owned EndpointSlice for the given Service.
+// Reconcile creates or updates the Aviator-owned EndpointSlices for the given
+// Service. Pods are grouped by their resolved ports, since every endpoint in a
+// slice shares the slice's ports, and each group is sharded across slices of
+// at most MaxEndpointsPerSlice endpoints. Slices owned by the policy that are
+// no longer needed are deleted; slices from earlier releases are adopted (see
+// ownedBy). The returned stats are valid even on error.
 func (m *Manager) Reconcile(
 	ctx context.Context,
 	policy *aviatorv1alpha1.AviatorPolicy,
 	service *corev1.Service,
 	selectedPods []PodEndpoint,
-) error {
-	sliceName := fmt.Sprintf("aviator-%s", service.Name)
+) (WriteStats, error) {
+	var stats WriteStats
+	hints := topologyHintsEnabled(service) && allZoned(selectedPods)
+	groups := groupByPorts(service, selectedPods)
 
-	// Build the desired EndpointSlice.
-	desired := m.buildEndpointSlice(sliceName, policy, service, selectedPods)
+	all, err := m.listSlices(ctx, service.Namespace, service.Name)
+	if err != nil {
+		return stats, err
+	}
+	baseName := fmt.Sprintf("aviator-%s", service.Name)
+	taken := make(map[string]bool, len(all))
+	byKey := make(map[string][]*discoveryv1.EndpointSlice)
+	for i := range all {
+		taken[all[i].Name] = true
+		if ownedBy(&all[i], policy) {
+			key := portsKey(all[i].Ports)
+			byKey[key] = append(byKey[key], &all[i])
+		}
+	}
 
-	// Try to get the existing slice.
-	existing := &discoveryv1.EndpointSlice{}
-	err := m.client.Get(ctx, types.NamespacedName{
-		Name:      sliceName,
-		Namespace: service.Namespace,
-	}, existing)
+	keep := make(map[string]bool, len(all))
+	for _, g := range groups {
+		for _, sh := range planShards(byKey[g.key], g.pods, m.maxEndpointsPerSlice()) {
+			desired := m.buildEndpointSlice(policy, service, g.ports, sh.pods, hints)
+			if sh.existing != nil {
+				desired.Name = sh.existing.Name
+			} else {
+				desired.Name = newSliceName(baseName, taken)
+			}
+			taken[desired.Name] = true
+			keep[desired.Name] = true
+			if err := m.apply(ctx, desired, sh.existing, &stats); err != nil {
+				return stats, err
+			}
+		}
+	}
 
-	if errors.IsNotFound(err) {
-		m.log.Info("creating EndpointSlice", "name", sliceName, "endpoints", len(selectedPods))
-		return m.client.Create(ctx, desired)
+	for i := range all {
+		if keep[all[i].Name] || !ownedBy(&all[i], policy) {
+			continue
+		}
+		m.log.Info("deleting unneeded EndpointSlice", "name", all[i].Name)
+		if err := m.client.Delete(ctx, &all[i]); err != nil && !errors.IsNotFound(err) {
+			return stats, fmt.Errorf("deleting EndpointSlice: %w", err)
+		}
+	}
+	return stats, nil
+}
+
+// ownedBy reports whether the slice belongs to the policy. Besides the
+// controller reference Aviator sets now, this accepts a plain owner reference
+// to the policy or its policy-name label, which is what slices written by
+// earlier releases carry, so that they are updated and garbage-collected
+// instead of left behind.
+func ownedBy(slice *discoveryv1.EndpointSlice, policy *aviatorv1alpha1.AviatorPolicy) bool {
+	if metav1.IsControlledBy(slice, policy) {
+		return true
 	}
+	for _, ref := range slice.OwnerReferences {
+		if ref.UID == policy.UID {
+			return true
+		}
+	}
+	return slice.Labels[PolicyNameLabel] == policy.Name
+}
+
+// apply writes the desired EndpointSlice with server-side apply, unless the
+// existing slice's content already hashes the same, so a slice edited by
+// hand is rewritten. A field ownership conflict from such an edit is counted
+// and the write retried with forced ownership: Aviator is the slice's only
+// writer.
+func (m *Manager) apply(
+	ctx context.Context,
+	desired, existing *discoveryv1.EndpointSlice,
+	stats *WriteStats,
+) error {
+	hash, err := contentHash(desired)
 	if err != nil {
-		return fmt.Errorf("getting EndpointSlice: %w", err)
+		return err
+	}
+	if existing != nil {
+		current, err := contentHash(existing)
+		if err != nil {
+			return err
+		}
+		if current == hash {
+			stats.Skipped++
+			return nil
+		}
 	}
 
-	// Update existing slice.
-	existing.Endpoints = desired.Endpoints
-	existing.Ports = desired.Ports
-	existing.Labels = desired.Labels
+	desired.TypeMeta = metav1.TypeMeta{
+		APIVersion: discoveryv1.SchemeGroupVersion.String(),
+		Kind:       "EndpointSlice",
+	}
+	desired.Annotations = map[string]string{ContentHashAnnotation: hash}
 
-	m.log.Info("updating EndpointSlice", "name", sliceName, "endpoints", len(selectedPods))
-	return m.client.Update(ctx, existing)
+	m.log.Info("applying EndpointSlice", "name", desired.Name, "endpoints", len(desired.Endpoints))
+	err = m.client.Patch(ctx, desired, client.Apply, client.FieldOwner(ManagedByValue))
+	if errors.IsConflict(err) {
+		stats.Conflicted++
+		m.log.Info("EndpointSlice field conflict, forcing ownership", "name", desired.Name, "conflict", err.Error())
+		err = m.client.Patch(ctx, desired, client.Apply, client.FieldOwner(ManagedByValue), client.ForceOwnership)
+	}
+	if err != nil {
+		return fmt.Errorf("applying EndpointSlice %s: %w", desired.Name, err)
+	}
+	stats.Applied++
+	return nil
+}
+
+// contentHash hashes everything Aviator applies to a slice.
+func contentHash(slice *discoveryv1.EndpointSlice) (string, error) {
+	data, err := json.Marshal(struct {
+		Labels          map[string]string
+		OwnerReferences []metav1.OwnerReference
+		AddressType     discoveryv1.AddressType
+		Endpoints       []discoveryv1.Endpoint
+		Ports           []discoveryv1.EndpointPort
+	}{slice.Labels, slice.OwnerReferences, slice.AddressType, slice.Endpoints, slice.Ports})
+	if err != nil {
+		return "", fmt.Errorf("hashing EndpointSlice: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// newSliceName returns baseName if it is free, or baseName with a random
+// suffix. Server-side apply needs a name up front, so generateName can't be
+// used.
+func newSliceName(baseName string, taken map[string]bool) string {
+	name := baseName
+	for taken[name] {
+		name = fmt.Sprintf("%s-%s", baseName, utilrand.String(5))
+	}
+	return name
 }
 
-// Cleanup removes the Aviator-owned EndpointSlice for a Service.
+func (m *Manager) maxEndpointsPerSlice() int {
+	switch {
+	case m.MaxEndpointsPerSlice <= 0:
+		return DefaultMaxEndpointsPerSlice
+	case m.MaxEndpointsPerSlice > MaxEndpointsPerSliceLimit:
+		return MaxEndpointsPerSliceLimit
+	}
+	return m.MaxEndpointsPerSlice
+}
+
+// listSlices returns the Aviator-owned EndpointSlices for a Service.
+func (m *Manager) listSlices(ctx context.Context, namespace, serviceName string) ([]discoveryv1.EndpointSlice, error) {
+	var list discoveryv1.EndpointSliceList
+	if err := m.client.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{
+		ManagedByLabel:   ManagedByValue,
+		ServiceNameLabel: serviceName,
+	}); err != nil {
+		return nil, fmt.Errorf("listing EndpointSlices: %w", err)
+	}
+	return list.Items, nil
+}
+
+// Cleanup removes the Aviator-owned EndpointSlices for a Service.
 func (m *Manager) Cleanup(ctx context.Context, namespace, serviceName string) error {
-	sliceName := fmt.Sprintf("aviator-%s", serviceName)
-	slice := &discoveryv1.EndpointSlice{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      sliceName,
-			Namespace: namespace,
-		},
+	slices, err := m.listSlices(ctx, namespace, serviceName)
+	if err != nil {
+		return err
+	}
+	for i := range slices {
+		if err := m.client.Delete(ctx, &slices[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// OtherSlices returns the EndpointSlices of a Service that Aviator does not
+// manage, such as kube-controller-manager's.
+func (m *Manager) OtherSlices(ctx context.Context, namespace, serviceName string) ([]discoveryv1.EndpointSlice, error) {
+	var list discoveryv1.EndpointSliceList
+	if err := m.client.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{
+		ServiceNameLabel: serviceName,
+	}); err != nil {
+		return nil, fmt.Errorf("listing EndpointSlices: %w", err)
+	}
+	other := list.Items[:0]
+	for _, slice := range list.Items {
+		if slice.Labels[ManagedByLabel] != ManagedByValue {
+			other = append(other, slice)
+		}
 	}
+	return other, nil
+}
 
-	err := m.client.Delete(ctx, slice)
-	if errors.IsNotFound(err) {
-		return nil
+// ServingPods returns the sorted names of the pods that a Service's
+// EndpointSlices currently mark ready, whoever manages the slices.
+func (m *Manager) ServingPods(ctx context.Context, namespace, serviceName string) ([]string, error) {
+	var list discoveryv1.EndpointSliceList
+	if err := m.client.List(ctx, &list, client.InNamespace(namespace), client.MatchingLabels{
+		ServiceNameLabel: serviceName,
+	}); err != nil {
+		return nil, fmt.Errorf("listing EndpointSlices: %w", err)
+	}
+	seen := make(map[string]bool)
+	var pods []string
+	for _, slice := range list.Items {
+		for _, ep := range slice.Endpoints {
+			if ep.TargetRef == nil || ep.TargetRef.Kind != "Pod" || seen[ep.TargetRef.Name] {
+				continue
+			}
+			// A nil ready condition means ready.
+			if ep.Conditions.Ready != nil && !*ep.Conditions.Ready {
+				continue
+			}
+			seen[ep.TargetRef.Name] = true
+			pods = append(pods, ep.TargetRef.Name)
+		}
 	}
-	return err
+	sort.Strings(pods)
+	return pods, nil
+}
+
+// RemoveOtherSlices deletes the EndpointSlices of a Service that Aviator does
+// not manage. Controllers stop maintaining slices once a Service loses its
+// selector, but leave the existing ones behind, still routing to every pod.
+func (m *Manager) RemoveOtherSlices(ctx context.Context, namespace, serviceName string) error {
+	slices, err := m.OtherSlices(ctx, namespace, serviceName)
+	if err != nil {
+		return err
+	}
+	for i := range slices {
+		m.log.Info("deleting EndpointSlice of taken-over Service", "name", slices[i].Name,
+			"managedBy", slices[i].Labels[ManagedByLabel])
+		if err := m.client.Delete(ctx, &slices[i]); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("deleting EndpointSlice: %w", err)
+		}
+	}
+	return nil
+}
+
+// ResolvePorts returns the EndpointSlice ports of a pod for the Service.
+// Numeric target ports are used as-is, defaulting to the Service port; named
+// target ports are looked up in the pod's container ports. Ports that cannot
+// be resolved are omitted, as the built-in EndpointSlice controller does. A
+// nil pod resolves numeric ports only.
+func ResolvePorts(service *corev1.Service, pod *corev1.Pod) []discoveryv1.EndpointPort {
+	ports := make([]discoveryv1.EndpointPort, 0, len(service.Spec.Ports))
+	for _, sp := range service.Spec.Ports {
+		port, ok := resolvePort(sp, pod)
+		if !ok {
+			continue
+		}
+		name := sp.Name
+		protocol := sp.Protocol
+		ports = append(ports, discoveryv1.EndpointPort{
+			Name:     &name,
+			Port:     &port,
+			Protocol: &protocol,
+		})
+	}
+	return ports
+}
+
+func resolvePort(sp corev1.ServicePort, pod *corev1.Pod) (int32, bool) {
+	if sp.TargetPort.Type == intstr.Int {
+		if sp.TargetPort.IntVal == 0 {
+			return sp.Port, true
+		}
+		return sp.TargetPort.IntVal, true
+	}
+	if pod == nil {
+		return 0, false
+	}
+	protocol := sp.Protocol
+	if protocol == "" {
+		protocol = corev1.ProtocolTCP
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, cp := range c.Ports {
+			cpProtocol := cp.Protocol
+			if cpProtocol == "" {
+				cpProtocol = corev1.ProtocolTCP
+			}
+			if cp.Name == sp.TargetPort.StrVal && cpProtocol == protocol {
+				return cp.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// portGroup is a set of pods that share the same resolved ports.
+type portGroup struct {
+	key   string
+	ports []discoveryv1.EndpointPort
+	pods  []PodEndpoint
+}
+
+// groupByPorts groups pods by their resolved ports, ordered by key. Pods
+// without resolved ports take the Service's numeric ports. With no pods, a
+// single empty group keeps a slice in place.
+func groupByPorts(service *corev1.Service, pods []PodEndpoint) []portGroup {
+	defaultPorts := ResolvePorts(service, nil)
+	if len(pods) == 0 {
+		return []portGroup{{key: portsKey(defaultPorts), ports: defaultPorts}}
+	}
+
+	byKey := make(map[string]*portGroup)
+	for _, pod := range pods {
+		ports := pod.Ports
+		if ports == nil {
+			ports = defaultPorts
+		}
+		key := portsKey(ports)
+		g, ok := byKey[key]
+		if !ok {
+			g = &portGroup{key: key, ports: ports}
+			byKey[key] = g
+		}
+		g.pods = append(g.pods, pod)
+	}
+
+	groups := make([]portGroup, 0, len(byKey))
+	for _, g := range byKey {
+		groups = append(groups, *g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].key < groups[j].key })
+	return groups
+}
+
+func portsKey(ports []discoveryv1.EndpointPort) string {
+	parts := make([]string, 0, len(ports))
+	for _, p := range ports {
+		var name string
+		var protocol corev1.Protocol
+		var port int32
+		if p.Name != nil {
+			name = *p.Name
+		}
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		if p.Port != nil {
+			port = *p.Port
+		}
+		parts = append(parts, fmt.Sprintf("%s/%s/%d", name, protocol, port))
+	}
+	sort.Strings(parts)
+	return strings.Join(parts, ",")
 }
 
 func (m *Manager) buildEndpointSlice(
-	name string,
 	policy *aviatorv1alpha1.AviatorPolicy,
 	service *corev1.Service,
+	ports []discoveryv1.EndpointPort,
 	pods []PodEndpoint,
+	hints bool,
 ) *discoveryv1.EndpointSlice {
 	addressType := discoveryv1.AddressTypeIPv4
 
 	endpoints := make([]discoveryv1.Endpoint, 0, len(pods))
 	for _, pod := range pods {
-		ready := pod.Ready
+		ready, serving, terminating := pod.Ready, pod.Serving, pod.Terminating
 		ep := discoveryv1.Endpoint{
 			Addresses: []string{pod.PodIP},
 			Conditions: discoveryv1.EndpointConditions{
-				Ready: &ready,
+				Ready:       &ready,
+				Serving:     &serving,
+				Terminating: &terminating,
 			},
 			TargetRef: &corev1.ObjectReference{
 				Kind:      "Pod",
@@ -138,28 +497,19 @@ func (m *Manager) buildEndpointSlice(
 		if pod.NodeName != "" {
 			ep.NodeName = &pod.NodeName
 		}
-		endpoints = append(endpoints, ep)
-	}
-
-	// Copy ports from the Service spec.
-	ports := make([]discoveryv1.EndpointPort, 0, len(service.Spec.Ports))
-	for _, sp := range service.Spec.Ports {
-		port := sp.TargetPort.IntVal
-		if port == 0 {
-			port = sp.Port
+		if pod.Zone != "" {
+			ep.Zone = &pod.Zone
 		}
-		name := sp.Name
-		protocol := sp.Protocol
-		ports = append(ports, discoveryv1.EndpointPort{
-			Name:     &name,
-			Port:     &port,
-			Protocol: &protocol,
-		})
+		if hints {
+			ep.Hints = &discoveryv1.EndpointHints{
+				ForZones: []discoveryv1.ForZone{{Name: pod.Zone}},
+			}
+		}
+		endpoints = append(endpoints, ep)
 	}
 
 	return &discoveryv1.EndpointSlice{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
 			Namespace: service.Namespace,
 			Labels: map[string]string{
 				ManagedByLabel:   ManagedByValue,
@@ -167,12 +517,7 @@ func (m *Manager) buildEndpointSlice(
 				PolicyNameLabel:  policy.Name,
 			},
 			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion: policy.APIVersion,
-					Kind:       policy.Kind,
-					Name:       policy.Name,
-					UID:        policy.UID,
-				},
+				*metav1.NewControllerRef(policy, aviatorv1alpha1.GroupVersion.WithKind("AviatorPolicy")),
 			},
 		},
 		AddressType: addressType,
@@ -180,3 +525,30 @@ func (m *Manager) buildEndpointSlice(
 		Ports:       ports,
 	}
 }
+
+// topologyHintsEnabled reports whether the Service opts into topology-aware
+// routing, in which case kube-proxy consumes zone hints from our slices.
+func topologyHintsEnabled(service *corev1.Service) bool {
+	if td := service.Spec.TrafficDistribution; td != nil && *td == corev1.ServiceTrafficDistributionPreferClose {
+		return true
+	}
+	mode, ok := service.Annotations[corev1.AnnotationTopologyMode]
+	if !ok {
+		mode = service.Annotations[corev1.DeprecatedAnnotationTopologyAwareHints]
+	}
+	return mode != "" && !strings.EqualFold(mode, "disabled")
+}
+
+// allZoned reports whether every pod has a zone. kube-proxy ignores hints for
+// the whole Service if any endpoint lacks them, so hints are all or nothing.
+func allZoned(pods []PodEndpoint) bool {
+	if len(pods) == 0 {
+		return false
+	}
+	for _, pod := range pods {
+		if pod.Zone == "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,424 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package endpointslice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+)
+
+func podWithReady(ready corev1.ConditionStatus, terminating bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+		},
+	}
+	if terminating {
+		now := metav1.Now()
+		pod.DeletionTimestamp = &now
+	}
+	return pod
+}
+
+func TestPodConditions(t *testing.T) {
+	tests := []struct {
+		name                             string
+		pod                              *corev1.Pod
+		publishNotReady                  bool
+		wantReady, wantServing, wantTerm bool
+	}{
+		{"ready", podWithReady(corev1.ConditionTrue, false), false, true, true, false},
+		{"not ready", podWithReady(corev1.ConditionFalse, false), false, false, false, false},
+		{"terminating and serving", podWithReady(corev1.ConditionTrue, true), false, false, true, true},
+		{"terminating and not serving", podWithReady(corev1.ConditionFalse, true), false, false, false, true},
+		{"publish not ready", podWithReady(corev1.ConditionFalse, false), true, true, false, false},
+		{"publish not ready while terminating", podWithReady(corev1.ConditionFalse, true), true, true, false, true},
+		{"publish not ready while terminating and serving", podWithReady(corev1.ConditionTrue, true), true, true, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ready, serving, terminating := PodConditions(tt.pod, tt.publishNotReady)
+			if ready != tt.wantReady || serving != tt.wantServing || terminating != tt.wantTerm {
+				t.Errorf("got ready=%v serving=%v terminating=%v, want %v %v %v",
+					ready, serving, terminating, tt.wantReady, tt.wantServing, tt.wantTerm)
+			}
+		})
+	}
+}
+
+func TestIsPodReady_NoCondition(t *testing.T) {
+	if IsPodReady(&corev1.Pod{}) {
+		t.Error("pod without a Ready condition should not be ready")
+	}
+}
+
+func namedPortService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80, TargetPort: intstr.FromString("http"), Protocol: corev1.ProtocolTCP},
+				{Name: "metrics", Port: 9090, TargetPort: intstr.FromInt32(9091), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+}
+
+func podWithPort(name string, port int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: "sidecar", Ports: []corev1.ContainerPort{{Name: "admin", ContainerPort: 15000}}},
+				{Name: "app", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: port}}},
+			},
+		},
+	}
+}
+
+func TestResolvePorts_NamedPort(t *testing.T) {
+	ports := ResolvePorts(namedPortService(), podWithPort("pod-a", 8080))
+
+	if len(ports) != 2 {
+		t.Fatalf("expected 2 ports, got %d", len(ports))
+	}
+	if *ports[0].Port != 8080 {
+		t.Errorf("expected named port to resolve to 8080, got %d", *ports[0].Port)
+	}
+	if *ports[1].Port != 9091 {
+		t.Errorf("expected numeric port 9091, got %d", *ports[1].Port)
+	}
+}
+
+func TestResolvePorts_Unresolvable(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+
+	ports := ResolvePorts(namedPortService(), pod)
+
+	if len(ports) != 1 || *ports[0].Name != "metrics" {
+		t.Errorf("expected only the numeric port, got %d ports", len(ports))
+	}
+}
+
+func TestReconcile_GroupsPodsByPort(t *testing.T) {
+	c := newTestClient(false)
+	m := NewManager(c, logr.Discard())
+
+	service := namedPortService()
+	policy := testPolicy()
+	endpoint := func(name, ip string, port int32) PodEndpoint {
+		return PodEndpoint{PodName: name, PodIP: ip, Ready: true, Serving: true,
+			Ports: ResolvePorts(service, podWithPort(name, port))}
+	}
+
+	ctx := context.Background()
+	_, err := m.Reconcile(ctx, policy, service, []PodEndpoint{
+		endpoint("pod-a", "10.0.0.1", 8080),
+		endpoint("pod-b", "10.0.0.2", 8080),
+		endpoint("pod-c", "10.0.0.3", 8081),
+	})
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+
+	slices := listTestSlices(t, c)
+	if len(slices) != 2 {
+		t.Fatalf("expected 2 slices, got %d", len(slices))
+	}
+	for _, slice := range slices {
+		port := *slice.Ports[0].Port
+		want := map[int32]int{8080: 2, 8081: 1}[port]
+		if len(slice.Endpoints) != want {
+			t.Errorf("slice for port %d: expected %d endpoints, got %d", port, want, len(slice.Endpoints))
+		}
+	}
+
+	// Once the odd pod is gone, its slice is garbage-collected.
+	_, err = m.Reconcile(ctx, policy, service, []PodEndpoint{endpoint("pod-a", "10.0.0.1", 8080)})
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if slices := listTestSlices(t, c); len(slices) != 1 || slices[0].Name != "aviator-web" {
+		t.Errorf("expected only aviator-web to remain, got %d slices", len(slices))
+	}
+}
+
+func TestReconcile_ShardsLargeSelections(t *testing.T) {
+	foreign := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-owner", Namespace: "default", Labels: map[string]string{
+			ManagedByLabel:   ManagedByValue,
+			ServiceNameLabel: "web",
+		}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	c := newTestClient(false, foreign)
+	m := NewManager(c, logr.Discard())
+	m.MaxEndpointsPerSlice = 2
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+	policy := testPolicy()
+	ctx := context.Background()
+
+	if _, err := m.Reconcile(ctx, policy, service, testPods(5)); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if slices := listTestSlices(t, c); len(slices) != 4 {
+		t.Fatalf("expected 3 owned slices and the foreign one, got %d", len(slices))
+	}
+
+	if _, err := m.Reconcile(ctx, policy, service, testPods(2)); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	slices := listTestSlices(t, c)
+	if len(slices) != 2 {
+		t.Fatalf("expected 1 owned slice and the foreign one, got %d", len(slices))
+	}
+	for _, slice := range slices {
+		if slice.Name != "other-owner" && !metav1.IsControlledBy(&slice, policy) {
+			t.Errorf("slice %s is not controlled by the policy", slice.Name)
+		}
+	}
+}
+
+func TestReconcile_SkipsUnchangedSlices(t *testing.T) {
+	c := newTestClient(false)
+	m := NewManager(c, logr.Discard())
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+	policy := testPolicy()
+	ctx := context.Background()
+
+	stats, err := m.Reconcile(ctx, policy, service, testPods(2))
+	if err != nil || stats.Applied != 1 {
+		t.Fatalf("expected one applied write, got %+v (err %v)", stats, err)
+	}
+	stats, err = m.Reconcile(ctx, policy, service, testPods(2))
+	if err != nil || stats.Applied != 0 || stats.Skipped != 1 {
+		t.Errorf("expected the unchanged slice to be skipped, got %+v (err %v)", stats, err)
+	}
+	stats, err = m.Reconcile(ctx, policy, service, testPods(3))
+	if err != nil || stats.Applied != 1 || stats.Skipped != 0 {
+		t.Errorf("expected the changed slice to be applied, got %+v (err %v)", stats, err)
+	}
+}
+
+func TestReconcile_RewritesEditedSlices(t *testing.T) {
+	c := newTestClient(false)
+	m := NewManager(c, logr.Discard())
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+	policy := testPolicy()
+	ctx := context.Background()
+
+	if _, err := m.Reconcile(ctx, policy, service, testPods(2)); err != nil {
+		t.Fatal(err)
+	}
+	// Someone drops an endpoint by hand; the content hash annotation stays.
+	edited := listTestSlices(t, c)[0]
+	edited.Endpoints = edited.Endpoints[:1]
+	if err := c.Update(ctx, &edited); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := m.Reconcile(ctx, policy, service, testPods(2))
+	if err != nil || stats.Applied != 1 || stats.Skipped != 0 {
+		t.Errorf("expected the edited slice to be applied, got %+v (err %v)", stats, err)
+	}
+	if got := listTestSlices(t, c)[0].Endpoints; len(got) != 2 {
+		t.Errorf("got %d endpoints, want the edit undone", len(got))
+	}
+}
+
+func TestReconcile_ForcesOwnershipOnConflict(t *testing.T) {
+	c := newTestClient(true)
+	m := NewManager(c, logr.Discard())
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+
+	stats, err := m.Reconcile(context.Background(), testPolicy(), service, testPods(1))
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if stats.Conflicted != 1 || stats.Applied != 1 {
+		t.Errorf("expected one conflicted and one applied write, got %+v", stats)
+	}
+}
+
+func TestReconcile_AdoptsSlicesFromEarlierReleases(t *testing.T) {
+	policy := testPolicy()
+	// Earlier releases set a plain owner reference, not a controller one.
+	legacy := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "aviator-web", Namespace: "default", Labels: map[string]string{
+			ManagedByLabel:   ManagedByValue,
+			ServiceNameLabel: "web",
+			PolicyNameLabel:  policy.Name,
+		}, OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "aviator.example.com/v1alpha1", Kind: "AviatorPolicy", Name: policy.Name, UID: policy.UID,
+		}}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	labelOnly := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{Name: "aviator-web-old", Namespace: "default", Labels: map[string]string{
+			ManagedByLabel:   ManagedByValue,
+			ServiceNameLabel: "web",
+			PolicyNameLabel:  policy.Name,
+		}},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	c := newTestClient(false, legacy, labelOnly)
+	m := NewManager(c, logr.Discard())
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+
+	if _, err := m.Reconcile(context.Background(), policy, service, testPods(2)); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	slices := listTestSlices(t, c)
+	if len(slices) != 1 {
+		t.Fatalf("expected the legacy slices to be reused or deleted, got %d slices", len(slices))
+	}
+	if !metav1.IsControlledBy(&slices[0], policy) || len(slices[0].Endpoints) != 2 {
+		t.Errorf("slice %s: controlled %v with %d endpoints, want the adopted slice with 2",
+			slices[0].Name, metav1.IsControlledBy(&slices[0], policy), len(slices[0].Endpoints))
+	}
+}
+
+func TestBuildEndpointSlice_ZonesAndHints(t *testing.T) {
+	m := NewManager(nil, logr.Discard())
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "http", Port: 80}}},
+	}
+	zoned := []PodEndpoint{
+		{PodName: "pod-a", PodIP: "10.0.0.1", NodeName: "node-a", Zone: "zone-a"},
+		{PodName: "pod-b", PodIP: "10.0.0.2", NodeName: "node-b", Zone: "zone-b"},
+	}
+
+	slice := m.buildEndpointSlice(testPolicy(), service, nil, zoned, true)
+	for i, ep := range slice.Endpoints {
+		want := zoned[i].Zone
+		if ep.Zone == nil || *ep.Zone != want {
+			t.Errorf("endpoint %d: zone = %v, want %s", i, ep.Zone, want)
+		}
+		if ep.Hints == nil || len(ep.Hints.ForZones) != 1 || ep.Hints.ForZones[0].Name != want {
+			t.Errorf("endpoint %d: hints = %+v, want for zone %s", i, ep.Hints, want)
+		}
+	}
+
+	// The node of pod-c carries no zone label. kube-proxy would ignore the
+	// hints of the whole Service, so none are set.
+	service.Annotations = map[string]string{corev1.AnnotationTopologyMode: "Auto"}
+	pods := append(zoned, PodEndpoint{PodName: "pod-c", PodIP: "10.0.0.3", NodeName: "node-c"})
+	c := newTestClient(false)
+	m = NewManager(c, logr.Discard())
+	if _, err := m.Reconcile(context.Background(), testPolicy(), service, pods); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	slices := listTestSlices(t, c)
+	if len(slices) != 1 || len(slices[0].Endpoints) != 3 {
+		t.Fatalf("expected one slice with 3 endpoints, got %d slices", len(slices))
+	}
+	for _, ep := range slices[0].Endpoints {
+		if ep.Hints != nil {
+			t.Errorf("endpoint %s: hints = %+v, want none", ep.TargetRef.Name, ep.Hints)
+		}
+		switch name := ep.TargetRef.Name; {
+		case name == "pod-c" && ep.Zone != nil:
+			t.Errorf("endpoint pod-c: zone = %q, want none", *ep.Zone)
+		case name != "pod-c" && ep.Zone == nil:
+			t.Errorf("endpoint %s: zone was dropped", name)
+		}
+	}
+
+	// With every pod zoned, the slice written carries hints.
+	if _, err := m.Reconcile(context.Background(), testPolicy(), service, zoned); err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	for _, ep := range listTestSlices(t, c)[0].Endpoints {
+		if ep.Hints == nil || ep.Hints.ForZones[0].Name != *ep.Zone {
+			t.Errorf("endpoint %s: hints = %+v, want for its zone", ep.TargetRef.Name, ep.Hints)
+		}
+	}
+}
+
+// newTestClient returns a fake client that emulates server-side apply, which
+// the fake client does not support, with a create or update. If conflict is
+// set, applies without forced ownership fail with a conflict.
+func newTestClient(conflict bool, objs ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() != types.ApplyPatchType {
+				return c.Patch(ctx, obj, patch, opts...)
+			}
+			po := &client.PatchOptions{}
+			po.ApplyOptions(opts)
+			if conflict && (po.Force == nil || !*po.Force) {
+				return apierrors.NewConflict(discoveryv1.Resource("endpointslices"), obj.GetName(), nil)
+			}
+			existing := obj.DeepCopyObject().(client.Object)
+			err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+			if apierrors.IsNotFound(err) {
+				return c.Create(ctx, obj)
+			}
+			if err != nil {
+				return err
+			}
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			return c.Update(ctx, obj)
+		},
+	}).Build()
+}
+
+func testPolicy() *aviatorv1alpha1.AviatorPolicy {
+	return &aviatorv1alpha1.AviatorPolicy{ObjectMeta: metav1.ObjectMeta{
+		Name:      "policy",
+		Namespace: "default",
+		UID:       "policy-uid",
+	}}
+}
+
+func listTestSlices(t *testing.T, c client.Client) []discoveryv1.EndpointSlice {
+	t.Helper()
+	var list discoveryv1.EndpointSliceList
+	if err := c.List(context.Background(), &list); err != nil {
+		t.Fatalf("listing slices: %v", err)
+	}
+	return list.Items
+}
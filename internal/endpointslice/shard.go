@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package endpointslice
+
+import (
+	"sort"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+const (
+	// DefaultMaxEndpointsPerSlice matches the built-in EndpointSlice controller.
+	DefaultMaxEndpointsPerSlice = 100
+	// MaxEndpointsPerSliceLimit is the API server's limit on endpoints per slice.
+	MaxEndpointsPerSliceLimit = 1000
+)
+
+// shard is the content of one EndpointSlice within a port group.
+type shard struct {
+	// existing is the slice that already holds these pods, or nil if a new
+	// slice is needed.
+	existing *discoveryv1.EndpointSlice
+	pods     []PodEndpoint
+}
+
+// planShards assigns a port group's pods to slices of at most maxPerSlice
+// endpoints. To limit churn, pods stay in the slice that already holds them;
+// the rest fill spare capacity in existing slices, in name order, before new
+// slices are added. Existing slices left empty are dropped, except that a
+// group without pods keeps a single slice.
+func planShards(existing []*discoveryv1.EndpointSlice, pods []PodEndpoint, maxPerSlice int) []shard {
+	sorted := append([]*discoveryv1.EndpointSlice(nil), existing...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	byIP := make(map[string]int, len(pods))
+	for i, pod := range pods {
+		byIP[pod.PodIP] = i
+	}
+
+	assigned := make([]bool, len(pods))
+	shards := make([]shard, 0, len(sorted))
+	for _, slice := range sorted {
+		sh := shard{existing: slice}
+		for _, ep := range slice.Endpoints {
+			if len(ep.Addresses) == 0 || len(sh.pods) >= maxPerSlice {
+				continue
+			}
+			i, ok := byIP[ep.Addresses[0]]
+			if !ok || assigned[i] {
+				continue
+			}
+			assigned[i] = true
+			sh.pods = append(sh.pods, pods[i])
+		}
+		shards = append(shards, sh)
+	}
+
+	next := 0
+	nextUnassigned := func() (PodEndpoint, bool) {
+		for ; next < len(pods); next++ {
+			if !assigned[next] {
+				assigned[next] = true
+				return pods[next], true
+			}
+		}
+		return PodEndpoint{}, false
+	}
+
+	for i := range shards {
+		for len(shards[i].pods) < maxPerSlice {
+			pod, ok := nextUnassigned()
+			if !ok {
+				break
+			}
+			shards[i].pods = append(shards[i].pods, pod)
+		}
+	}
+	for {
+		pod, ok := nextUnassigned()
+		if !ok {
+			break
+		}
+		if n := len(shards); n == 0 || shards[n-1].existing != nil || len(shards[n-1].pods) >= maxPerSlice {
+			shards = append(shards, shard{})
+		}
+		shards[len(shards)-1].pods = append(shards[len(shards)-1].pods, pod)
+	}
+
+	kept := shards[:0]
+	for _, sh := range shards {
+		if len(sh.pods) > 0 {
+			kept = append(kept, sh)
+		}
+	}
+	if len(kept) == 0 {
+		if len(sorted) > 0 {
+			return []shard{{existing: sorted[0]}}
+		}
+		return []shard{{}}
+	}
+	return kept
+}
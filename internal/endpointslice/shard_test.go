@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package endpointslice
+
+import (
+	"fmt"
+	"testing"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testPods(n int) []PodEndpoint {
+	pods := make([]PodEndpoint, n)
+	for i := range pods {
+		pods[i] = PodEndpoint{PodName: fmt.Sprintf("pod-%d", i), PodIP: fmt.Sprintf("10.0.0.%d", i)}
+	}
+	return pods
+}
+
+func testSlice(name string, ips ...string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	for _, ip := range ips {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{Addresses: []string{ip}})
+	}
+	return slice
+}
+
+func TestPlanShards_SplitsAtLimit(t *testing.T) {
+	shards := planShards(nil, testPods(5), 2)
+
+	if len(shards) != 3 {
+		t.Fatalf("expected 3 shards, got %d", len(shards))
+	}
+	for i, want := range []int{2, 2, 1} {
+		if len(shards[i].pods) != want || shards[i].existing != nil {
+			t.Errorf("shard %d: expected %d pods in a new slice, got %d", i, want, len(shards[i].pods))
+		}
+	}
+}
+
+func TestPlanShards_KeepsAssignments(t *testing.T) {
+	pods := testPods(4)
+	existing := []*discoveryv1.EndpointSlice{
+		testSlice("b", "10.0.0.0", "10.0.0.9"), // pod 9 is gone
+		testSlice("a", "10.0.0.3", "10.0.0.2"),
+	}
+
+	shards := planShards(existing, pods, 2)
+
+	if len(shards) != 2 {
+		t.Fatalf("expected 2 shards, got %d", len(shards))
+	}
+	a, b := shards[0], shards[1]
+	if a.existing.Name != "a" || a.pods[0].PodName != "pod-3" || a.pods[1].PodName != "pod-2" {
+		t.Errorf("slice a should keep pod-3 and pod-2 in order, got %+v", a.pods)
+	}
+	// pod-0 stays; pod-1 fills the space left by the removed pod.
+	if b.existing.Name != "b" || len(b.pods) != 2 || b.pods[0].PodName != "pod-0" || b.pods[1].PodName != "pod-1" {
+		t.Errorf("slice b should hold pod-0 and pod-1, got %+v", b.pods)
+	}
+}
+
+func TestPlanShards_DropsEmptySlices(t *testing.T) {
+	existing := []*discoveryv1.EndpointSlice{
+		testSlice("a", "10.0.0.0"),
+		testSlice("b", "10.0.0.9"),
+	}
+
+	shards := planShards(existing, testPods(1), 100)
+
+	if len(shards) != 1 || shards[0].existing.Name != "a" {
+		t.Errorf("expected only slice a to remain, got %d shards", len(shards))
+	}
+}
+
+func TestPlanShards_NoPodsKeepsOneSlice(t *testing.T) {
+	shards := planShards([]*discoveryv1.EndpointSlice{testSlice("b"), testSlice("a")}, nil, 100)
+
+	if len(shards) != 1 || shards[0].existing.Name != "a" {
+		t.Errorf("expected slice a to be kept, got %d shards", len(shards))
+	}
+	if shards := planShards(nil, nil, 100); len(shards) != 1 || shards[0].existing != nil {
+		t.Error("expected a single new slice")
+	}
+}
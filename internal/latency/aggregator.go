@@ -20,6 +20,17 @@ type PodRanking struct {
 	PodName string
 	PodIP   string
 	Stats   Stats
+	// Zone is the topology zone of the pod's node, if known.
+	Zone string
+	// Estimated is set when Stats is a warm-up estimate, not a measurement.
+	Estimated bool
+
+	// Resources holds non-latency signals used by ScorePods.
+	Resources ResourceSignals
+	// Score is the composite score set by ScorePods (0 best, 1 worst).
+	Score float64
+	// ScoreBreakdown holds each signal's weighted contribution to Score.
+	ScoreBreakdown map[Signal]float64
 }
 
 // RankPods sorts pods by P99 latency (lowest first).
@@ -68,6 +79,146 @@ func SelectByThreshold(ranked []PodRanking, threshold time.Duration) []PodRankin
 	return selected
 }
 
+// EnsureZoneMinimum adds pods to selected until every zone present in ranked
+// has at least minPerZone selected pods, or all of its pods if it has fewer.
+// Pods are added in ranked order and the result preserves that order. Pods
+// without a zone are not counted towards any zone.
+func EnsureZoneMinimum(ranked, selected []PodRanking, minPerZone int) []PodRanking {
+	if minPerZone <= 0 {
+		return selected
+	}
+
+	chosen := make(map[string]bool, len(selected))
+	perZone := make(map[string]int)
+	for _, p := range selected {
+		chosen[p.PodIP] = true
+		if p.Zone != "" {
+			perZone[p.Zone]++
+		}
+	}
+
+	added := false
+	for _, p := range ranked {
+		if p.Zone == "" || chosen[p.PodIP] || perZone[p.Zone] >= minPerZone {
+			continue
+		}
+		chosen[p.PodIP] = true
+		perZone[p.Zone]++
+		added = true
+	}
+	if !added {
+		return selected
+	}
+
+	result := make([]PodRanking, 0, len(chosen))
+	for _, p := range ranked {
+		if chosen[p.PodIP] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// EnsureMinimum adds pods from ranked, in order, until selected holds at least
+// minPods pods or ranked is exhausted. The result preserves the order of ranked.
+func EnsureMinimum(ranked, selected []PodRanking, minPods int) []PodRanking {
+	if len(selected) >= minPods {
+		return selected
+	}
+
+	chosen := make(map[string]bool, minPods)
+	for _, p := range selected {
+		chosen[p.PodIP] = true
+	}
+	for _, p := range ranked {
+		if len(chosen) >= minPods {
+			break
+		}
+		chosen[p.PodIP] = true
+	}
+
+	result := make([]PodRanking, 0, len(chosen))
+	for _, p := range ranked {
+		if chosen[p.PodIP] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// OutlierMethod selects how SelectNonOutliers measures distance from the median.
+type OutlierMethod int
+
+const (
+	// OutlierMedianRatio flags pods whose P99 exceeds factor × median.
+	OutlierMedianRatio OutlierMethod = iota
+	// OutlierMAD flags pods whose P99 exceeds median + factor × MAD.
+	OutlierMAD
+)
+
+// madFloorDivisor bounds the MAD from below at median/20 (5%), so a perfectly
+// uniform fleet does not turn every microsecond of jitter into an outlier.
+const madFloorDivisor = 20
+
+// SelectNonOutliers returns every pod except those whose P99 is an outlier
+// relative to the fleet median. Only pods with at least minSamples samples
+// contribute to the median and can be excluded; at least two such pods are
+// required, otherwise all pods are returned.
+func SelectNonOutliers(ranked []PodRanking, method OutlierMethod, factor float64, minSamples int64) []PodRanking {
+	var judged []time.Duration
+	for _, p := range ranked {
+		if p.Stats.SampleCount >= minSamples {
+			judged = append(judged, p.Stats.P99)
+		}
+	}
+	if len(judged) < 2 {
+		return ranked
+	}
+
+	median := medianDuration(judged)
+	var limit time.Duration
+	switch method {
+	case OutlierMAD:
+		deviations := make([]time.Duration, len(judged))
+		for i, d := range judged {
+			deviations[i] = absDuration(d - median)
+		}
+		mad := medianDuration(deviations)
+		if floor := median / madFloorDivisor; mad < floor {
+			mad = floor
+		}
+		limit = median + time.Duration(factor*float64(mad))
+	default:
+		limit = time.Duration(factor * float64(median))
+	}
+
+	selected := make([]PodRanking, 0, len(ranked))
+	for _, p := range ranked {
+		if p.Stats.SampleCount >= minSamples && p.Stats.P99 > limit {
+			continue
+		}
+		selected = append(selected, p)
+	}
+	return selected
+}
+
+// medianDuration returns the median of d. It sorts d in place.
+func medianDuration(d []time.Duration) time.Duration {
+	sort.Slice(d, func(i, j int) bool { return d[i] < d[j] })
+	mid := len(d) / 2
+	if len(d)%2 == 0 {
+		return (d[mid-1] + d[mid]) / 2
+	}
+	return d[mid]
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
 // ComputeFleetP99 computes the P99 across all pods in the fleet.
 func ComputeFleetP99(pods []PodRanking) time.Duration {
 	if len(pods) == 0 {
@@ -101,11 +252,99 @@ func ComputeFleetAverage(pods []PodRanking) time.Duration {
 type DampeningState struct {
 	previousSelected []string
 	violationCount   int
+
+	// Per-pod hysteresis state, keyed by pod IP.
+	pods map[string]*hysteresisState
+}
+
+type hysteresisState struct {
+	active bool
+	count  int
+}
+
+// defaultHysteresisIntervals is used for EntryIntervals and ExitIntervals
+// below 1, matching the CRD default.
+const defaultHysteresisIntervals = 3
+
+// HysteresisConfig holds per-pod hysteresis parameters. EntryThreshold should
+// be below ExitThreshold so pods near a single threshold do not oscillate.
+// Intervals below 1 mean the default of 3.
+type HysteresisConfig struct {
+	EntryThreshold time.Duration
+	ExitThreshold  time.Duration
+	EntryIntervals int
+	ExitIntervals  int
 }
 
 // NewDampeningState creates a new dampening tracker.
 func NewDampeningState() *DampeningState {
-	return &DampeningState{}
+	return &DampeningState{
+		pods: make(map[string]*hysteresisState),
+	}
+}
+
+// FilterHysteresis records one interval of observations and returns the pods
+// that are currently active. An active pod is deactivated once its P99 has
+// exceeded ExitThreshold for ExitIntervals consecutive intervals; an inactive
+// pod is reactivated once its P99 has stayed below EntryThreshold for
+// EntryIntervals consecutive intervals. Newly seen pods start active, pods
+// with estimated stats bypass hysteresis, and pods no longer present are
+// forgotten.
+func (d *DampeningState) FilterHysteresis(ranked []PodRanking, cfg HysteresisConfig) []PodRanking {
+	if cfg.EntryIntervals < 1 {
+		cfg.EntryIntervals = defaultHysteresisIntervals
+	}
+	if cfg.ExitIntervals < 1 {
+		cfg.ExitIntervals = defaultHysteresisIntervals
+	}
+	seen := make(map[string]bool, len(ranked))
+	active := make([]PodRanking, 0, len(ranked))
+	for _, p := range ranked {
+		seen[p.PodIP] = true
+		if p.Estimated {
+			active = append(active, p)
+			continue
+		}
+
+		st, ok := d.pods[p.PodIP]
+		if !ok {
+			st = &hysteresisState{active: true}
+			d.pods[p.PodIP] = st
+		}
+
+		if st.active {
+			if p.Stats.P99 > cfg.ExitThreshold {
+				st.count++
+			} else {
+				st.count = 0
+			}
+			if st.count >= cfg.ExitIntervals {
+				st.active = false
+				st.count = 0
+			}
+		} else {
+			if p.Stats.P99 < cfg.EntryThreshold {
+				st.count++
+			} else {
+				st.count = 0
+			}
+			if st.count >= cfg.EntryIntervals {
+				st.active = true
+				st.count = 0
+			}
+		}
+
+		if st.active {
+			active = append(active, p)
+		}
+	}
+
+	for ip := range d.pods {
+		if !seen[ip] {
+			delete(d.pods, ip)
+		}
+	}
+	return active
 }
 
 // ShouldUpdate returns true if the new pod set differs enough from the previous
@@ -133,6 +372,11 @@ func (d *DampeningState) ShouldUpdate(newSelected []string, thresholdPercent int
 	return false
 }
 
+// Current returns the most recently applied selection.
+func (d *DampeningState) Current() []string {
+	return d.previousSelected
+}
+
 func (d *DampeningState) computeChangePercent(newSelected []string) float64 {
 	if len(d.previousSelected) == 0 {
 		return 100
@@ -117,6 +117,148 @@ func TestSelectByThreshold_AllExceed(t *testing.T) {
 	}
 }
 
+func TestSelectNonOutliers_UniformFleetKeepsAll(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-b", Stats: Stats{P99: 11 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-c", Stats: Stats{P99: 12 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-d", Stats: Stats{P99: 13 * time.Millisecond, SampleCount: 100}},
+	}
+
+	if selected := SelectNonOutliers(pods, OutlierMedianRatio, 3, 10); len(selected) != 4 {
+		t.Errorf("median: expected all 4 pods kept, got %d", len(selected))
+	}
+	if selected := SelectNonOutliers(pods, OutlierMAD, 3, 10); len(selected) != 4 {
+		t.Errorf("mad: expected all 4 pods kept, got %d", len(selected))
+	}
+}
+
+func TestSelectNonOutliers_MedianRatio(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-b", Stats: Stats{P99: 12 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-c", Stats: Stats{P99: 14 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-d", Stats: Stats{P99: 100 * time.Millisecond, SampleCount: 100}},
+	}
+
+	// Median is 13ms, so the limit at k=3 is 39ms.
+	selected := SelectNonOutliers(pods, OutlierMedianRatio, 3, 10)
+	if len(selected) != 3 {
+		t.Fatalf("expected 3 pods, got %d", len(selected))
+	}
+	for _, p := range selected {
+		if p.PodName == "pod-d" {
+			t.Error("pod-d should be excluded as an outlier")
+		}
+	}
+}
+
+func TestSelectNonOutliers_MAD(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-b", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-c", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-d", Stats: Stats{P99: 25 * time.Millisecond, SampleCount: 100}},
+	}
+
+	// MAD is 0, floored to 0.5ms, so the limit at k=3 is 11.5ms.
+	selected := SelectNonOutliers(pods, OutlierMAD, 3, 10)
+	if len(selected) != 3 {
+		t.Fatalf("expected 3 pods, got %d", len(selected))
+	}
+
+	// The same fleet under the median ratio keeps pod-d (25ms < 30ms).
+	if selected := SelectNonOutliers(pods, OutlierMedianRatio, 3, 10); len(selected) != 4 {
+		t.Errorf("median: expected all 4 pods kept, got %d", len(selected))
+	}
+}
+
+func TestSelectNonOutliers_MinSamples(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-b", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-c", Stats: Stats{P99: 900 * time.Millisecond, SampleCount: 2}},
+	}
+
+	// pod-c has too few samples to be judged, so it stays in rotation.
+	selected := SelectNonOutliers(pods, OutlierMedianRatio, 3, 10)
+	if len(selected) != 3 {
+		t.Fatalf("expected 3 pods, got %d", len(selected))
+	}
+
+	// With fewer than two judged pods, nothing is excluded.
+	selected = SelectNonOutliers(pods[1:], OutlierMedianRatio, 3, 10)
+	if len(selected) != 2 {
+		t.Fatalf("expected 2 pods, got %d", len(selected))
+	}
+}
+
+func TestEnsureZoneMinimum(t *testing.T) {
+	ranked := []PodRanking{
+		{PodName: "pod-a", PodIP: "10.0.0.1", Zone: "zone-a", Stats: Stats{P99: 10 * time.Millisecond}},
+		{PodName: "pod-b", PodIP: "10.0.0.2", Zone: "zone-a", Stats: Stats{P99: 20 * time.Millisecond}},
+		{PodName: "pod-c", PodIP: "10.0.0.3", Zone: "zone-b", Stats: Stats{P99: 30 * time.Millisecond}},
+		{PodName: "pod-d", PodIP: "10.0.0.4", Zone: "zone-b", Stats: Stats{P99: 40 * time.Millisecond}},
+		{PodName: "pod-e", PodIP: "10.0.0.5", Zone: "zone-c", Stats: Stats{P99: 50 * time.Millisecond}},
+	}
+
+	// 40% of 5 selects only zone-a pods.
+	selected := SelectTopPercent(ranked, 40)
+	result := EnsureZoneMinimum(ranked, selected, 1)
+
+	if len(result) != 4 {
+		t.Fatalf("expected 4 pods, got %d", len(result))
+	}
+	want := []string{"pod-a", "pod-b", "pod-c", "pod-e"}
+	for i, name := range want {
+		if result[i].PodName != name {
+			t.Errorf("position %d: expected %s, got %s", i, name, result[i].PodName)
+		}
+	}
+}
+
+func TestEnsureZoneMinimum_ZoneSmallerThanMinimum(t *testing.T) {
+	ranked := []PodRanking{
+		{PodName: "pod-a", PodIP: "10.0.0.1", Zone: "zone-a"},
+		{PodName: "pod-b", PodIP: "10.0.0.2", Zone: "zone-a"},
+		{PodName: "pod-c", PodIP: "10.0.0.3", Zone: "zone-b"},
+		{PodName: "pod-d", PodIP: "10.0.0.4"},
+	}
+
+	result := EnsureZoneMinimum(ranked, ranked[:1], 2)
+	if len(result) != 3 {
+		t.Fatalf("expected 3 pods (zone-less pod not added), got %d", len(result))
+	}
+}
+
+func TestEnsureMinimum(t *testing.T) {
+	ranked := []PodRanking{
+		{PodName: "pod-a", PodIP: "10.0.0.1", Stats: Stats{P99: 10 * time.Millisecond}},
+		{PodName: "pod-b", PodIP: "10.0.0.2", Stats: Stats{P99: 200 * time.Millisecond}},
+		{PodName: "pod-c", PodIP: "10.0.0.3", Stats: Stats{P99: 300 * time.Millisecond}},
+	}
+
+	// Threshold mode falls back to only the fastest pod.
+	selected := SelectByThreshold(ranked, 100*time.Millisecond)
+	result := EnsureMinimum(ranked, selected, 2)
+	if len(result) != 2 {
+		t.Fatalf("expected 2 pods, got %d", len(result))
+	}
+	if result[0].PodName != "pod-a" || result[1].PodName != "pod-b" {
+		t.Errorf("expected pod-a and pod-b, got %s and %s", result[0].PodName, result[1].PodName)
+	}
+
+	// A minimum above the fleet size returns every pod.
+	if result := EnsureMinimum(ranked, selected, 10); len(result) != 3 {
+		t.Errorf("expected all 3 pods, got %d", len(result))
+	}
+
+	// A satisfied minimum leaves the selection unchanged.
+	if result := EnsureMinimum(ranked, ranked[:2], 1); len(result) != 2 {
+		t.Errorf("expected 2 pods, got %d", len(result))
+	}
+}
+
 func TestComputeFleetP99(t *testing.T) {
 	pods := []PodRanking{
 		{Stats: Stats{P99: 10 * time.Millisecond}},
@@ -180,3 +322,74 @@ func TestDampeningState_AllowAfterConsecutive(t *testing.T) {
 		t.Error("should apply after 2 consecutive intervals exceeding threshold")
 	}
 }
+
+func TestDampeningState_HysteresisExit(t *testing.T) {
+	d := NewDampeningState()
+	cfg := HysteresisConfig{
+		EntryThreshold: 50 * time.Millisecond,
+		ExitThreshold:  100 * time.Millisecond,
+		EntryIntervals: 2,
+		ExitIntervals:  2,
+	}
+	slow := []PodRanking{{PodName: "pod-a", PodIP: "10.0.0.1", Stats: Stats{P99: 150 * time.Millisecond}}}
+
+	if active := d.FilterHysteresis(slow, cfg); len(active) != 1 {
+		t.Fatal("pod should stay active after 1 interval above exit threshold")
+	}
+	if active := d.FilterHysteresis(slow, cfg); len(active) != 0 {
+		t.Fatal("pod should be removed after 2 intervals above exit threshold")
+	}
+}
+
+func TestDampeningState_HysteresisDefaultIntervals(t *testing.T) {
+	d := NewDampeningState()
+	cfg := HysteresisConfig{EntryThreshold: 50 * time.Millisecond, ExitThreshold: 100 * time.Millisecond}
+	fast := []PodRanking{{PodName: "pod-a", PodIP: "10.0.0.1", Stats: Stats{P99: 10 * time.Millisecond}}}
+	slow := []PodRanking{{PodName: "pod-a", PodIP: "10.0.0.1", Stats: Stats{P99: 150 * time.Millisecond}}}
+
+	if active := d.FilterHysteresis(fast, cfg); len(active) != 1 {
+		t.Fatal("a fast pod should stay active when intervals are unset")
+	}
+	for i := 0; i < 2; i++ {
+		if active := d.FilterHysteresis(slow, cfg); len(active) != 1 {
+			t.Fatalf("pod removed after %d slow intervals, want the default of 3", i+1)
+		}
+	}
+	if active := d.FilterHysteresis(slow, cfg); len(active) != 0 {
+		t.Fatal("pod should be removed after 3 slow intervals")
+	}
+}
+
+func TestDampeningState_HysteresisEntry(t *testing.T) {
+	d := NewDampeningState()
+	cfg := HysteresisConfig{
+		EntryThreshold: 50 * time.Millisecond,
+		ExitThreshold:  100 * time.Millisecond,
+		EntryIntervals: 2,
+		ExitIntervals:  1,
+	}
+	pod := func(p99 time.Duration) []PodRanking {
+		return []PodRanking{{PodName: "pod-a", PodIP: "10.0.0.1", Stats: Stats{P99: p99}}}
+	}
+
+	d.FilterHysteresis(pod(150*time.Millisecond), cfg) // removed
+
+	// Between the thresholds: stays out, however long.
+	for i := 0; i < 3; i++ {
+		if active := d.FilterHysteresis(pod(80*time.Millisecond), cfg); len(active) != 0 {
+			t.Fatal("pod between thresholds should not be re-added")
+		}
+	}
+
+	if active := d.FilterHysteresis(pod(40*time.Millisecond), cfg); len(active) != 0 {
+		t.Fatal("pod should need 2 intervals below entry threshold")
+	}
+	if active := d.FilterHysteresis(pod(40*time.Millisecond), cfg); len(active) != 1 {
+		t.Fatal("pod should be re-added after 2 intervals below entry threshold")
+	}
+
+	// Between the thresholds again: stays in.
+	if active := d.FilterHysteresis(pod(80*time.Millisecond), cfg); len(active) != 1 {
+		t.Fatal("pod between thresholds should stay active")
+	}
+}
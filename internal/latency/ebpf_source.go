@@ -70,8 +70,9 @@ func (s *EBPFSource) Ready(ctx context.Context) bool {
 	return len(s.agentEndpoints) > 0
 }
 
-// GetLatencies aggregates latency data from all known eBPF agents.
-func (s *EBPFSource) GetLatencies(ctx context.Context, podIPs []string) (map[string]Stats, error) {
+// GetLatencies aggregates latency data from all known eBPF agents. The agents
+// observe existing traffic, so ports is ignored.
+func (s *EBPFSource) GetLatencies(ctx context.Context, podIPs []string, _ map[string]int32) (map[string]Stats, error) {
 	s.mu.RLock()
 	endpoints := make([]string, len(s.agentEndpoints))
 	copy(endpoints, s.agentEndpoints)
@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ExplorationMode defines how excluded pods are explored.
+type ExplorationMode int
+
+const (
+	// ExploreEpsilonGreedy reinstates each excluded pod with probability
+	// Epsilon per evaluation.
+	ExploreEpsilonGreedy ExplorationMode = iota
+	// ExplorePeriodic reinstates each excluded pod once per Interval.
+	ExplorePeriodic
+	// ExploreProbe selects each excluded pod for probe traffic once per
+	// Interval without returning it to rotation.
+	ExploreProbe
+)
+
+// ExplorationConfig holds exploration parameters.
+type ExplorationConfig struct {
+	Mode ExplorationMode
+	// Epsilon is the per-evaluation exploration probability (0–1).
+	Epsilon float64
+	// Interval between explorations of the same pod.
+	Interval time.Duration
+	// Duration a reinstated pod stays in rotation.
+	Duration time.Duration
+}
+
+type explorationState struct {
+	lastExplored   time.Time
+	exploringUntil time.Time
+}
+
+// Explorer decides when pods excluded by selection are briefly returned to
+// rotation (or probed), so that passive latency measurements of them stay
+// fresh and recovered pods can earn their way back.
+type Explorer struct {
+	mu         sync.Mutex
+	pods       map[string]*explorationState // keyed by pod IP
+	lastActive []string
+	rand       func() float64
+}
+
+// NewExplorer creates a new exploration tracker.
+func NewExplorer() *Explorer {
+	return &Explorer{
+		pods: make(map[string]*explorationState),
+		rand: rand.Float64,
+	}
+}
+
+// Explore returns the excluded pods to explore now, and whether that set
+// differs from the previous call. A pod that has just been excluded waits a
+// full Interval before its first periodic or probe exploration. Pods no
+// longer excluded are forgotten.
+func (e *Explorer) Explore(excluded []string, cfg ExplorationConfig, now time.Time) ([]string, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	current := make(map[string]bool, len(excluded))
+	var active []string
+	for _, ip := range excluded {
+		current[ip] = true
+		st, ok := e.pods[ip]
+		if !ok {
+			st = &explorationState{lastExplored: now}
+			e.pods[ip] = st
+		}
+
+		if now.Before(st.exploringUntil) {
+			active = append(active, ip)
+			continue
+		}
+
+		start := false
+		switch cfg.Mode {
+		case ExploreEpsilonGreedy:
+			start = e.rand() < cfg.Epsilon
+		case ExplorePeriodic, ExploreProbe:
+			start = now.Sub(st.lastExplored) >= cfg.Interval
+		}
+		if !start {
+			continue
+		}
+
+		st.lastExplored = now
+		if cfg.Mode != ExploreProbe {
+			st.exploringUntil = now.Add(cfg.Duration)
+		}
+		active = append(active, ip)
+	}
+
+	for ip := range e.pods {
+		if !current[ip] {
+			delete(e.pods, ip)
+		}
+	}
+
+	sort.Strings(active)
+	changed := !equalStrings(active, e.lastActive)
+	e.lastActive = active
+	return active, changed
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExplorer_Periodic(t *testing.T) {
+	e := NewExplorer()
+	cfg := ExplorationConfig{Mode: ExplorePeriodic, Interval: time.Minute, Duration: 10 * time.Second}
+	start := time.Now()
+
+	// Newly excluded pods wait a full interval.
+	if active, _ := e.Explore([]string{"10.0.0.1"}, cfg, start); len(active) != 0 {
+		t.Fatalf("expected no exploration right after exclusion, got %v", active)
+	}
+
+	active, changed := e.Explore([]string{"10.0.0.1"}, cfg, start.Add(time.Minute))
+	if len(active) != 1 || !changed {
+		t.Fatalf("expected 10.0.0.1 reinstated after interval, got %v (changed=%v)", active, changed)
+	}
+
+	// Stays in rotation for Duration.
+	active, changed = e.Explore([]string{"10.0.0.1"}, cfg, start.Add(time.Minute+5*time.Second))
+	if len(active) != 1 || changed {
+		t.Fatalf("expected 10.0.0.1 still exploring and unchanged, got %v (changed=%v)", active, changed)
+	}
+
+	active, changed = e.Explore([]string{"10.0.0.1"}, cfg, start.Add(time.Minute+10*time.Second))
+	if len(active) != 0 || !changed {
+		t.Fatalf("expected exploration to end, got %v (changed=%v)", active, changed)
+	}
+}
+
+func TestExplorer_EpsilonGreedy(t *testing.T) {
+	e := NewExplorer()
+	rolls := []float64{0.5, 0.05}
+	e.rand = func() float64 {
+		r := rolls[0]
+		rolls = rolls[1:]
+		return r
+	}
+	cfg := ExplorationConfig{Mode: ExploreEpsilonGreedy, Epsilon: 0.1, Duration: 10 * time.Second}
+	now := time.Now()
+
+	if active, _ := e.Explore([]string{"10.0.0.1"}, cfg, now); len(active) != 0 {
+		t.Fatalf("expected no exploration on roll 0.5, got %v", active)
+	}
+	if active, _ := e.Explore([]string{"10.0.0.1"}, cfg, now.Add(5*time.Second)); len(active) != 1 {
+		t.Fatalf("expected exploration on roll 0.05, got %v", active)
+	}
+}
+
+func TestExplorer_ProbeIsOneShot(t *testing.T) {
+	e := NewExplorer()
+	cfg := ExplorationConfig{Mode: ExploreProbe, Interval: time.Minute, Duration: 10 * time.Second}
+	start := time.Now()
+
+	e.Explore([]string{"10.0.0.1"}, cfg, start)
+	if active, _ := e.Explore([]string{"10.0.0.1"}, cfg, start.Add(time.Minute)); len(active) != 1 {
+		t.Fatalf("expected probe after interval, got %v", active)
+	}
+	if active, _ := e.Explore([]string{"10.0.0.1"}, cfg, start.Add(time.Minute+time.Second)); len(active) != 0 {
+		t.Fatalf("expected probe to be one-shot, got %v", active)
+	}
+}
+
+func TestExplorer_ForgetsReadmittedPods(t *testing.T) {
+	e := NewExplorer()
+	cfg := ExplorationConfig{Mode: ExplorePeriodic, Interval: time.Minute, Duration: 10 * time.Second}
+	start := time.Now()
+
+	e.Explore([]string{"10.0.0.1"}, cfg, start)
+	e.Explore(nil, cfg, start.Add(30*time.Second))
+
+	// Excluded again: the interval restarts.
+	if active, _ := e.Explore([]string{"10.0.0.1"}, cfg, start.Add(time.Minute)); len(active) != 0 {
+		t.Fatalf("expected interval to restart after re-exclusion, got %v", active)
+	}
+}
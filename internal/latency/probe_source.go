@@ -49,8 +49,9 @@ func (s *ProbeSource) Name() string { return "probe" }
 
 func (s *ProbeSource) Ready(_ context.Context) bool { return true }
 
-// GetLatencies probes each pod IP and returns latency statistics.
-func (s *ProbeSource) GetLatencies(ctx context.Context, podIPs []string) (map[string]Stats, error) {
+// GetLatencies probes each pod IP, on its entry in ports if any, and returns
+// latency statistics.
+func (s *ProbeSource) GetLatencies(ctx context.Context, podIPs []string, ports map[string]int32) (map[string]Stats, error) {
 	if len(podIPs) == 0 {
 		return nil, nil
 	}
@@ -67,7 +68,11 @@ func (s *ProbeSource) GetLatencies(ctx context.Context, podIPs []string) (map[st
 		wg.Add(1)
 		go func(podIP string) {
 			defer wg.Done()
-			stat := s.probePod(ctx, podIP)
+			port := s.port
+			if p := ports[podIP]; p > 0 {
+				port = p
+			}
+			stat := s.probePod(ctx, podIP, port)
 			results <- result{ip: podIP, stat: stat}
 		}(ip)
 	}
@@ -86,9 +91,12 @@ func (s *ProbeSource) GetLatencies(ctx context.Context, podIPs []string) (map[st
 }
 
 // probePod sends multiple HTTP probes and computes latency stats.
-func (s *ProbeSource) probePod(ctx context.Context, podIP string) Stats {
-	url := fmt.Sprintf("http://%s:%d/", podIP, s.port)
+func (s *ProbeSource) probePod(ctx context.Context, podIP string, port int32) Stats {
+	url := fmt.Sprintf("http://%s:%d/", podIP, port)
 	samples := make([]time.Duration, 0, probeSamplesPerRound)
+	failures := 0
+	var trailing int64
+	var connected, succeeded bool
 
 	for i := 0; i < probeSamplesPerRound; i++ {
 		start := time.Now()
@@ -96,6 +104,8 @@ func (s *ProbeSource) probePod(ctx context.Context, podIP string) Stats {
 		if err != nil {
 			s.log.V(1).Info("failed to create probe request", "podIP", podIP, "error", err)
 			samples = append(samples, unreachableLatency)
+			failures++
+			trailing++
 			continue
 		}
 
@@ -105,9 +115,19 @@ func (s *ProbeSource) probePod(ctx context.Context, podIP string) Stats {
 		if err != nil {
 			s.log.V(1).Info("probe failed", "podIP", podIP, "error", err)
 			samples = append(samples, unreachableLatency)
+			failures++
+			trailing++
 			continue
 		}
 		resp.Body.Close()
+		connected = true
+		if resp.StatusCode >= http.StatusInternalServerError {
+			failures++
+			trailing++
+		} else {
+			succeeded = true
+			trailing = 0
+		}
 		samples = append(samples, latency)
 	}
 
@@ -117,6 +137,8 @@ func (s *ProbeSource) probePod(ctx context.Context, podIP string) Stats {
 			P99:         unreachableLatency,
 			SampleCount: 0,
 			LastUpdated: time.Now(),
+			AllFailed:   true,
+			Unreachable: true,
 		}
 	}
 
@@ -124,10 +146,14 @@ func (s *ProbeSource) probePod(ctx context.Context, podIP string) Stats {
 	sortDurations(samples)
 
 	return Stats{
-		P50:         percentile(samples, 50),
-		P99:         percentile(samples, 99),
-		SampleCount: int64(len(samples)),
-		LastUpdated: time.Now(),
+		P50:              percentile(samples, 50),
+		P99:              percentile(samples, 99),
+		SampleCount:      int64(len(samples)),
+		LastUpdated:      time.Now(),
+		ErrorRate:        float64(failures) / float64(len(samples)),
+		TrailingFailures: trailing,
+		AllFailed:        !succeeded,
+		Unreachable:      !connected,
 	}
 }
 
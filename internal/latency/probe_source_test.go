@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// probeTarget returns the IP and port of a server handling each probe with
+// the next status in statuses.
+func probeTarget(t *testing.T, statuses ...int) (string, int32) {
+	t.Helper()
+	var n int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(statuses[n%len(statuses)])
+		n++
+	}))
+	t.Cleanup(srv.Close)
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, int32(p)
+}
+
+func TestProbeFailures(t *testing.T) {
+	ip, port := probeTarget(t, http.StatusServiceUnavailable, http.StatusOK, http.StatusServiceUnavailable)
+	stats := NewProbeSource(logr.Discard(), port).probePod(context.Background(), ip, port)
+	if stats.TrailingFailures != 1 || stats.AllFailed || stats.Unreachable {
+		t.Errorf("got trailing %d, all failed %v, unreachable %v; want 1, false, false",
+			stats.TrailingFailures, stats.AllFailed, stats.Unreachable)
+	}
+
+	ip, port = probeTarget(t, http.StatusServiceUnavailable)
+	stats = NewProbeSource(logr.Discard(), port).probePod(context.Background(), ip, port)
+	if stats.TrailingFailures != probeSamplesPerRound || !stats.AllFailed || stats.Unreachable {
+		t.Errorf("fast 503s: got trailing %d, all failed %v, unreachable %v",
+			stats.TrailingFailures, stats.AllFailed, stats.Unreachable)
+	}
+}
+
+func TestProbeUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	stats := NewProbeSource(logr.Discard(), int32(port)).probePod(context.Background(), "127.0.0.1", int32(port))
+	if !stats.Unreachable || !stats.AllFailed || stats.TrailingFailures != probeSamplesPerRound {
+		t.Errorf("got unreachable %v, all failed %v, trailing %d", stats.Unreachable, stats.AllFailed, stats.TrailingFailures)
+	}
+}
+
+func TestProbePerPodPort(t *testing.T) {
+	ip, port := probeTarget(t, http.StatusOK)
+	// The default port is closed; only the per-pod port reaches the server.
+	stats, err := NewProbeSource(logr.Discard(), 1).GetLatencies(context.Background(), []string{ip}, map[string]int32{ip: port})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := stats[ip]; s.Unreachable || s.AllFailed {
+		t.Errorf("got unreachable %v, all failed %v; want the per-pod port probed", s.Unreachable, s.AllFailed)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"sort"
+)
+
+// Signal names a single input to the composite score.
+type Signal string
+
+const (
+	SignalP50       Signal = "p50"
+	SignalP99       Signal = "p99"
+	SignalErrorRate Signal = "errorRate"
+	SignalCPU       Signal = "cpu"
+	SignalMemory    Signal = "memory"
+	SignalRestarts  Signal = "restarts"
+)
+
+// ResourceSignals holds the non-latency signals for a pod.
+type ResourceSignals struct {
+	// CPUUtilization is CPU usage as a fraction of the pod's CPU request.
+	CPUUtilization float64
+	// MemoryUtilization is memory usage as a fraction of the pod's memory request.
+	MemoryUtilization float64
+	// Restarts is the total container restart count.
+	Restarts int32
+}
+
+// Weights holds the relative weight of each signal. Zero disables a signal.
+type Weights struct {
+	P50       float64
+	P99       float64
+	ErrorRate float64
+	CPU       float64
+	Memory    float64
+	Restarts  float64
+}
+
+type weightedSignal struct {
+	signal Signal
+	weight float64
+	value  func(p PodRanking) float64
+}
+
+func (w Weights) signals() []weightedSignal {
+	all := []weightedSignal{
+		{SignalP50, w.P50, func(p PodRanking) float64 { return float64(p.Stats.P50) }},
+		{SignalP99, w.P99, func(p PodRanking) float64 { return float64(p.Stats.P99) }},
+		{SignalErrorRate, w.ErrorRate, func(p PodRanking) float64 { return p.Stats.ErrorRate }},
+		{SignalCPU, w.CPU, func(p PodRanking) float64 { return p.Resources.CPUUtilization }},
+		{SignalMemory, w.Memory, func(p PodRanking) float64 { return p.Resources.MemoryUtilization }},
+		{SignalRestarts, w.Restarts, func(p PodRanking) float64 { return float64(p.Resources.Restarts) }},
+	}
+	active := all[:0]
+	for _, s := range all {
+		if s.weight > 0 {
+			active = append(active, s)
+		}
+	}
+	return active
+}
+
+// ScorePods computes a composite score for each pod and sorts pods by it
+// (lowest first). Every signal is min-max normalised across the fleet to
+// [0, 1] and weighted; the score is the weighted mean, so it also lies in
+// [0, 1]. Each pod's ScoreBreakdown records the contribution of every signal.
+// If no weight is positive, pods are scored on P99 alone.
+func ScorePods(pods []PodRanking, weights Weights) []PodRanking {
+	signals := weights.signals()
+	if len(signals) == 0 {
+		signals = Weights{P99: 1}.signals()
+	}
+
+	var totalWeight float64
+	for _, s := range signals {
+		totalWeight += s.weight
+	}
+
+	for i := range pods {
+		pods[i].Score = 0
+		pods[i].ScoreBreakdown = make(map[Signal]float64, len(signals))
+	}
+
+	for _, s := range signals {
+		lo, hi := signalRange(pods, s.value)
+		for i := range pods {
+			var normalised float64
+			if hi > lo {
+				normalised = (s.value(pods[i]) - lo) / (hi - lo)
+			}
+			contribution := normalised * s.weight / totalWeight
+			pods[i].ScoreBreakdown[s.signal] = contribution
+			pods[i].Score += contribution
+		}
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[i].Score < pods[j].Score
+	})
+	return pods
+}
+
+func signalRange(pods []PodRanking, value func(PodRanking) float64) (lo, hi float64) {
+	for i, p := range pods {
+		v := value(p)
+		if i == 0 || v < lo {
+			lo = v
+		}
+		if i == 0 || v > hi {
+			hi = v
+		}
+	}
+	return lo, hi
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestScorePods_DefaultsToP99(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-c", Stats: Stats{P99: 300 * time.Millisecond}},
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond}},
+		{PodName: "pod-b", Stats: Stats{P99: 50 * time.Millisecond}},
+	}
+
+	scored := ScorePods(pods, Weights{})
+
+	if scored[0].PodName != "pod-a" || scored[2].PodName != "pod-c" {
+		t.Errorf("expected P99 ordering, got %s, %s, %s", scored[0].PodName, scored[1].PodName, scored[2].PodName)
+	}
+	if scored[0].Score != 0 || scored[2].Score != 1 {
+		t.Errorf("expected scores normalised to [0, 1], got %v and %v", scored[0].Score, scored[2].Score)
+	}
+}
+
+func TestScorePods_Weighted(t *testing.T) {
+	pods := []PodRanking{
+		// Fastest, but erroring heavily.
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond, ErrorRate: 0.5}},
+		{PodName: "pod-b", Stats: Stats{P99: 20 * time.Millisecond, ErrorRate: 0}},
+	}
+
+	scored := ScorePods(pods, Weights{P99: 1, ErrorRate: 3})
+
+	if scored[0].PodName != "pod-b" {
+		t.Fatalf("expected pod-b to rank first, got %s", scored[0].PodName)
+	}
+	if got := scored[0].ScoreBreakdown[SignalP99]; math.Abs(got-0.25) > 1e-9 {
+		t.Errorf("expected pod-b P99 contribution 0.25, got %v", got)
+	}
+	if got := scored[1].ScoreBreakdown[SignalErrorRate]; math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("expected pod-a error rate contribution 0.75, got %v", got)
+	}
+}
+
+func TestScorePods_ResourceSignals(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Resources: ResourceSignals{CPUUtilization: 0.9, Restarts: 4}},
+		{PodName: "pod-b", Resources: ResourceSignals{CPUUtilization: 0.2, Restarts: 0}},
+		{PodName: "pod-c", Resources: ResourceSignals{CPUUtilization: 0.5, Restarts: 1}},
+	}
+
+	scored := ScorePods(pods, Weights{CPU: 1, Restarts: 1})
+
+	if scored[0].PodName != "pod-b" || scored[2].PodName != "pod-a" {
+		t.Errorf("unexpected ordering: %s, %s, %s", scored[0].PodName, scored[1].PodName, scored[2].PodName)
+	}
+	if _, ok := scored[0].ScoreBreakdown[SignalP99]; ok {
+		t.Error("zero-weight signals should not appear in the breakdown")
+	}
+}
+
+func TestScorePods_UniformSignal(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond}},
+		{PodName: "pod-b", Stats: Stats{P99: 10 * time.Millisecond}},
+	}
+
+	for _, p := range ScorePods(pods, Weights{P99: 1}) {
+		if p.Score != 0 {
+			t.Errorf("expected score 0 for uniform fleet, got %v", p.Score)
+		}
+	}
+}
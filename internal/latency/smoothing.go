@@ -0,0 +1,215 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SmoothingMethod selects how per-pod latency history is combined.
+type SmoothingMethod int
+
+const (
+	// SmoothEWMA uses an exponentially weighted moving average with a
+	// time-based half-life.
+	SmoothEWMA SmoothingMethod = iota
+	// SmoothWindowQuantile uses a quantile of the snapshots within a window.
+	SmoothWindowQuantile
+)
+
+// retentionFactor sets how long a pod's history survives without new data,
+// as a multiple of the half-life or window.
+const retentionFactor = 4
+
+// SmoothingConfig holds smoothing parameters.
+type SmoothingConfig struct {
+	Method SmoothingMethod
+	// HalfLife is the EWMA half-life.
+	HalfLife time.Duration
+	// Window is the history length for SmoothWindowQuantile.
+	Window time.Duration
+	// Quantile (1–100) taken over the window for SmoothWindowQuantile.
+	Quantile int
+}
+
+type observation struct {
+	at    time.Time
+	stats Stats
+}
+
+type podSeries struct {
+	ewmaP50, ewmaP99 float64
+	errorRate        float64
+	samples          int64
+	last             time.Time
+	history          []observation
+}
+
+// Smoother keeps a per-pod latency time series across evaluations, so a
+// single noisy snapshot cannot reshuffle the whole ranking.
+type Smoother struct {
+	mu   sync.Mutex
+	pods map[string]*podSeries // keyed by pod IP
+}
+
+// NewSmoother creates a new latency smoother.
+func NewSmoother() *Smoother {
+	return &Smoother{pods: make(map[string]*podSeries)}
+}
+
+// Smooth folds the latest snapshot into each pod's history and returns
+// smoothed stats for the pods in latest. SampleCount in the result is the
+// number of samples behind the smoothed value: cumulative for EWMA, within
+// the window for SmoothWindowQuantile. History of pods absent for several
+// half-lives (or windows) is discarded.
+func (s *Smoother) Smooth(latest map[string]Stats, cfg SmoothingConfig, now time.Time) map[string]Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := make(map[string]Stats, len(latest))
+	for ip, stat := range latest {
+		ps, ok := s.pods[ip]
+		if !ok {
+			ps = &podSeries{}
+			s.pods[ip] = ps
+		}
+		if cfg.Method == SmoothWindowQuantile {
+			result[ip] = ps.observeWindow(stat, cfg, now)
+		} else {
+			result[ip] = ps.observeEWMA(stat, cfg, now)
+		}
+	}
+
+	retention := cfg.HalfLife
+	if cfg.Method == SmoothWindowQuantile {
+		retention = cfg.Window
+	}
+	retention *= retentionFactor
+	for ip, ps := range s.pods {
+		if _, ok := latest[ip]; !ok && now.Sub(ps.last) > retention {
+			delete(s.pods, ip)
+		}
+	}
+	return result
+}
+
+func (ps *podSeries) observeEWMA(stat Stats, cfg SmoothingConfig, now time.Time) Stats {
+	if ps.last.IsZero() || cfg.HalfLife <= 0 {
+		ps.ewmaP50 = float64(stat.P50)
+		ps.ewmaP99 = float64(stat.P99)
+		ps.errorRate = stat.ErrorRate
+	} else {
+		dt := now.Sub(ps.last)
+		alpha := 1 - math.Exp2(-float64(dt)/float64(cfg.HalfLife))
+		ps.ewmaP50 += alpha * (float64(stat.P50) - ps.ewmaP50)
+		ps.ewmaP99 += alpha * (float64(stat.P99) - ps.ewmaP99)
+		ps.errorRate += alpha * (stat.ErrorRate - ps.errorRate)
+	}
+	ps.samples += stat.SampleCount
+	ps.last = now
+
+	return Stats{
+		P50:              time.Duration(ps.ewmaP50),
+		P99:              time.Duration(ps.ewmaP99),
+		SampleCount:      ps.samples,
+		LastUpdated:      stat.LastUpdated,
+		ErrorRate:        ps.errorRate,
+		TrailingFailures: stat.TrailingFailures,
+		AllFailed:        stat.AllFailed,
+		Unreachable:      stat.Unreachable,
+	}
+}
+
+func (ps *podSeries) observeWindow(stat Stats, cfg SmoothingConfig, now time.Time) Stats {
+	ps.history = append(ps.history, observation{at: now, stats: stat})
+	ps.last = now
+
+	cutoff := now.Add(-cfg.Window)
+	keep := ps.history[:0]
+	for _, o := range ps.history {
+		if !o.at.Before(cutoff) {
+			keep = append(keep, o)
+		}
+	}
+	ps.history = keep
+
+	p50s := make([]time.Duration, len(keep))
+	p99s := make([]time.Duration, len(keep))
+	var samples int64
+	var errorRate float64
+	for i, o := range keep {
+		p50s[i] = o.stats.P50
+		p99s[i] = o.stats.P99
+		samples += o.stats.SampleCount
+		errorRate += o.stats.ErrorRate
+	}
+	sort.Slice(p50s, func(i, j int) bool { return p50s[i] < p50s[j] })
+	sort.Slice(p99s, func(i, j int) bool { return p99s[i] < p99s[j] })
+
+	return Stats{
+		P50:              windowQuantile(p50s, cfg.Quantile),
+		P99:              windowQuantile(p99s, cfg.Quantile),
+		SampleCount:      samples,
+		LastUpdated:      stat.LastUpdated,
+		ErrorRate:        errorRate / float64(len(keep)),
+		TrailingFailures: stat.TrailingFailures,
+		AllFailed:        stat.AllFailed,
+		Unreachable:      stat.Unreachable,
+	}
+}
+
+// windowQuantile returns the nearest-rank quantile of sorted values.
+func windowQuantile(sorted []time.Duration, q int) time.Duration {
+	idx := int(math.Ceil(float64(q)*float64(len(sorted))/100)) - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+// ShrinkLowConfidence pulls the stats of pods with fewer than minSamples
+// samples towards the median of the pods that have enough, in proportion to
+// how far short they fall: a pod with no samples sits at the median, a pod
+// at minSamples keeps its own stats. Estimated pods are left unchanged. At
+// least one confident pod is required, otherwise nothing changes.
+func ShrinkLowConfidence(pods []PodRanking, minSamples int64) []PodRanking {
+	if minSamples <= 0 {
+		return pods
+	}
+
+	var confident []PodRanking
+	for _, p := range pods {
+		if !p.Estimated && p.Stats.SampleCount >= minSamples {
+			confident = append(confident, p)
+		}
+	}
+	if len(confident) == 0 {
+		return pods
+	}
+	prior := EstimateWarmUpStats(confident, WarmUpMedian, 0)
+
+	for i := range pods {
+		p := &pods[i]
+		if p.Estimated || p.Stats.SampleCount >= minSamples {
+			continue
+		}
+		w := float64(p.Stats.SampleCount) / float64(minSamples)
+		p.Stats.P50 = interpolate(prior.P50, p.Stats.P50, w)
+		p.Stats.P99 = interpolate(prior.P99, p.Stats.P99, w)
+	}
+	return pods
+}
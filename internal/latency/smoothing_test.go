@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSmoother_EWMAHalfLife(t *testing.T) {
+	s := NewSmoother()
+	cfg := SmoothingConfig{Method: SmoothEWMA, HalfLife: 10 * time.Second}
+	start := time.Now()
+
+	s.Smooth(map[string]Stats{"10.0.0.1": {P99: 100 * time.Millisecond, SampleCount: 3}}, cfg, start)
+
+	// After one half-life, a spike moves the average halfway.
+	got := s.Smooth(map[string]Stats{"10.0.0.1": {P99: 300 * time.Millisecond, SampleCount: 3}}, cfg, start.Add(10*time.Second))
+	if got["10.0.0.1"].P99 != 200*time.Millisecond {
+		t.Errorf("expected 200ms after one half-life, got %v", got["10.0.0.1"].P99)
+	}
+	if got["10.0.0.1"].SampleCount != 6 {
+		t.Errorf("expected 6 cumulative samples, got %d", got["10.0.0.1"].SampleCount)
+	}
+}
+
+func TestSmoother_WindowQuantile(t *testing.T) {
+	s := NewSmoother()
+	cfg := SmoothingConfig{Method: SmoothWindowQuantile, Window: 30 * time.Second, Quantile: 50}
+	start := time.Now()
+
+	for i, p99 := range []time.Duration{10, 900, 20} {
+		s.Smooth(map[string]Stats{"10.0.0.1": {P99: p99 * time.Millisecond, SampleCount: 3}}, cfg, start.Add(time.Duration(i)*5*time.Second))
+	}
+	got := s.Smooth(map[string]Stats{"10.0.0.1": {P99: 15 * time.Millisecond, SampleCount: 3}}, cfg, start.Add(15*time.Second))
+	if got["10.0.0.1"].P99 != 15*time.Millisecond {
+		t.Errorf("expected median of window to ignore the spike, got %v", got["10.0.0.1"].P99)
+	}
+
+	// Old observations fall out of the window.
+	got = s.Smooth(map[string]Stats{"10.0.0.1": {P99: 40 * time.Millisecond, SampleCount: 3}}, cfg, start.Add(60*time.Second))
+	if got["10.0.0.1"].P99 != 40*time.Millisecond || got["10.0.0.1"].SampleCount != 3 {
+		t.Errorf("expected only the latest observation in window, got %v (%d samples)", got["10.0.0.1"].P99, got["10.0.0.1"].SampleCount)
+	}
+}
+
+func TestSmoother_ForgetsStalePods(t *testing.T) {
+	s := NewSmoother()
+	cfg := SmoothingConfig{Method: SmoothEWMA, HalfLife: time.Second}
+	start := time.Now()
+
+	s.Smooth(map[string]Stats{"10.0.0.1": {P99: 100 * time.Millisecond}}, cfg, start)
+	s.Smooth(map[string]Stats{}, cfg, start.Add(time.Minute))
+
+	got := s.Smooth(map[string]Stats{"10.0.0.1": {P99: 10 * time.Millisecond}}, cfg, start.Add(time.Minute+time.Second))
+	if got["10.0.0.1"].P99 != 10*time.Millisecond {
+		t.Errorf("expected history to restart, got %v", got["10.0.0.1"].P99)
+	}
+}
+
+func TestShrinkLowConfidence(t *testing.T) {
+	pods := []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P99: 10 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-b", Stats: Stats{P99: 30 * time.Millisecond, SampleCount: 100}},
+		{PodName: "pod-c", Stats: Stats{P99: 20 * time.Millisecond, SampleCount: 100}},
+		// One lucky sample.
+		{PodName: "pod-d", Stats: Stats{P99: 1 * time.Millisecond, SampleCount: 2}},
+	}
+
+	ShrinkLowConfidence(pods, 10)
+
+	// 20% own value, 80% fleet median (20ms).
+	want := time.Duration(0.2*float64(time.Millisecond) + 0.8*float64(20*time.Millisecond))
+	if pods[3].Stats.P99 != want {
+		t.Errorf("expected %v, got %v", want, pods[3].Stats.P99)
+	}
+	if pods[0].Stats.P99 != 10*time.Millisecond {
+		t.Error("confident pods should be unchanged")
+	}
+}
@@ -21,13 +21,25 @@ type Stats struct {
 	P99         time.Duration
 	SampleCount int64
 	LastUpdated time.Time
+	// ErrorRate is the fraction of samples that failed (0–1), if the source
+	// can observe failures.
+	ErrorRate float64
+	// TrailingFailures counts the failed samples after the last successful
+	// one, and AllFailed is set when none succeeded. Unreachable is set when
+	// no sample could connect. Only active sources report them, and
+	// smoothing passes the latest values through.
+	TrailingFailures int64
+	AllFailed        bool
+	Unreachable      bool
 }
 
 // Source is the interface that latency measurement backends must implement.
 type Source interface {
 	// GetLatencies returns latency statistics for a set of pod IPs.
-	// The returned map is keyed by pod IP.
-	GetLatencies(ctx context.Context, podIPs []string) (map[string]Stats, error)
+	// The returned map is keyed by pod IP. ports maps pod IPs to the port
+	// to use in place of the source's default, for sources that connect to
+	// pods directly; it may be nil.
+	GetLatencies(ctx context.Context, podIPs []string, ports map[string]int32) (map[string]Stats, error)
 
 	// Name returns a human-readable name for the latency source.
 	Name() string
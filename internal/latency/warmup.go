@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"time"
+)
+
+// WarmUpMode defines how a pod without latency data is treated while warming up.
+type WarmUpMode int
+
+const (
+	// WarmUpExclude drops unmeasured pods from ranking.
+	WarmUpExclude WarmUpMode = iota
+	// WarmUpInclude keeps unmeasured pods in rotation, bypassing selection.
+	WarmUpInclude
+	// WarmUpMedian ranks unmeasured pods at the fleet median.
+	WarmUpMedian
+	// WarmUpSlowStart ranks unmeasured pods at the fleet's slowest latency
+	// and moves them towards the median as the warm-up window elapses.
+	WarmUpSlowStart
+)
+
+// WarmUpProgress returns the fraction (0–1) of the warm-up window that has
+// elapsed for a pod that became ready at since.
+func WarmUpProgress(since, now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		return 1
+	}
+	elapsed := now.Sub(since)
+	if elapsed <= 0 {
+		return 0
+	}
+	if elapsed >= window {
+		return 1
+	}
+	return float64(elapsed) / float64(window)
+}
+
+// EstimateWarmUpStats returns synthetic stats that place an unmeasured pod
+// within the measured fleet. WarmUpMedian uses the fleet median; for
+// WarmUpSlowStart the estimate moves linearly from the fleet maximum to the
+// median as progress goes from 0 to 1. The estimate has no samples.
+func EstimateWarmUpStats(measured []PodRanking, mode WarmUpMode, progress float64) Stats {
+	if len(measured) == 0 {
+		return Stats{LastUpdated: time.Now()}
+	}
+
+	p50s := make([]time.Duration, len(measured))
+	p99s := make([]time.Duration, len(measured))
+	for i, p := range measured {
+		p50s[i] = p.Stats.P50
+		p99s[i] = p.Stats.P99
+	}
+	medP50, medP99 := medianDuration(p50s), medianDuration(p99s)
+	if mode != WarmUpSlowStart {
+		return Stats{P50: medP50, P99: medP99, LastUpdated: time.Now()}
+	}
+
+	// medianDuration sorted the slices, so the maximum is last.
+	maxP50, maxP99 := p50s[len(p50s)-1], p99s[len(p99s)-1]
+	return Stats{
+		P50:         interpolate(maxP50, medP50, progress),
+		P99:         interpolate(maxP99, medP99, progress),
+		LastUpdated: time.Now(),
+	}
+}
+
+func interpolate(from, to time.Duration, t float64) time.Duration {
+	return from + time.Duration(t*float64(to-from))
+}
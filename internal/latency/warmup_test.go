@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package latency
+
+import (
+	"testing"
+	"time"
+)
+
+func warmUpFleet() []PodRanking {
+	return []PodRanking{
+		{PodName: "pod-a", Stats: Stats{P50: 5 * time.Millisecond, P99: 10 * time.Millisecond}},
+		{PodName: "pod-b", Stats: Stats{P50: 10 * time.Millisecond, P99: 20 * time.Millisecond}},
+		{PodName: "pod-c", Stats: Stats{P50: 50 * time.Millisecond, P99: 100 * time.Millisecond}},
+	}
+}
+
+func TestWarmUpProgress(t *testing.T) {
+	start := time.Now()
+	if p := WarmUpProgress(start, start.Add(15*time.Second), time.Minute); p != 0.25 {
+		t.Errorf("expected progress 0.25, got %v", p)
+	}
+	if p := WarmUpProgress(start, start.Add(2*time.Minute), time.Minute); p != 1 {
+		t.Errorf("expected progress clamped to 1, got %v", p)
+	}
+	if p := WarmUpProgress(start, start.Add(-time.Second), time.Minute); p != 0 {
+		t.Errorf("expected progress clamped to 0, got %v", p)
+	}
+}
+
+func TestEstimateWarmUpStats_Median(t *testing.T) {
+	fleet := warmUpFleet()
+	stats := EstimateWarmUpStats(fleet, WarmUpMedian, 0)
+	if stats.P99 != 20*time.Millisecond || stats.P50 != 10*time.Millisecond {
+		t.Errorf("expected median stats, got P50=%v P99=%v", stats.P50, stats.P99)
+	}
+	if stats.SampleCount != 0 {
+		t.Errorf("estimate should carry no samples, got %d", stats.SampleCount)
+	}
+	if fleet[0].PodName != "pod-a" || fleet[2].Stats.P99 != 100*time.Millisecond {
+		t.Error("estimate should not reorder the measured fleet")
+	}
+}
+
+func TestEstimateWarmUpStats_SlowStart(t *testing.T) {
+	fleet := warmUpFleet()
+
+	if stats := EstimateWarmUpStats(fleet, WarmUpSlowStart, 0); stats.P99 != 100*time.Millisecond {
+		t.Errorf("expected slowest P99 at start, got %v", stats.P99)
+	}
+	if stats := EstimateWarmUpStats(fleet, WarmUpSlowStart, 0.5); stats.P99 != 60*time.Millisecond {
+		t.Errorf("expected 60ms P99 halfway, got %v", stats.P99)
+	}
+	if stats := EstimateWarmUpStats(fleet, WarmUpSlowStart, 1); stats.P99 != 20*time.Millisecond {
+		t.Errorf("expected median P99 at end, got %v", stats.P99)
+	}
+}
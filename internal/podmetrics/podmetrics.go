@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package podmetrics
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// podMetricsListGVK is read as unstructured so the controller does not depend
+// on the metrics API types or require metrics-server to be installed.
+var podMetricsListGVK = schema.GroupVersionKind{
+	Group:   "metrics.k8s.io",
+	Version: "v1beta1",
+	Kind:    "PodMetricsList",
+}
+
+// Utilization is a pod's resource usage as a fraction of its requests.
+type Utilization struct {
+	CPU    float64
+	Memory float64
+}
+
+// GetUtilization reads current usage from the metrics.k8s.io API and returns
+// utilisation for the given pods, keyed by pod name. Pods without metrics are
+// omitted.
+func GetUtilization(ctx context.Context, c client.Reader, namespace string, pods []corev1.Pod) (map[string]Utilization, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(podMetricsListGVK)
+	if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("listing pod metrics: %w", err)
+	}
+
+	usage := make(map[string]corev1.ResourceList, len(list.Items))
+	for _, item := range list.Items {
+		rl, err := containerUsage(item)
+		if err != nil {
+			return nil, fmt.Errorf("parsing metrics for pod %s: %w", item.GetName(), err)
+		}
+		usage[item.GetName()] = rl
+	}
+
+	result := make(map[string]Utilization, len(pods))
+	for _, pod := range pods {
+		if u, ok := usage[pod.Name]; ok {
+			result[pod.Name] = ComputeUtilization(pod, u)
+		}
+	}
+	return result, nil
+}
+
+// ComputeUtilization divides usage by the pod's total container requests,
+// falling back to limits for a resource with no request. A resource with
+// neither reports zero utilisation.
+func ComputeUtilization(pod corev1.Pod, usage corev1.ResourceList) Utilization {
+	var cpuReq, memReq int64
+	for _, c := range pod.Spec.Containers {
+		cpuReq += requestOrLimit(c.Resources, corev1.ResourceCPU).MilliValue()
+		memReq += requestOrLimit(c.Resources, corev1.ResourceMemory).Value()
+	}
+
+	var u Utilization
+	if cpu, ok := usage[corev1.ResourceCPU]; ok && cpuReq > 0 {
+		u.CPU = float64(cpu.MilliValue()) / float64(cpuReq)
+	}
+	if mem, ok := usage[corev1.ResourceMemory]; ok && memReq > 0 {
+		u.Memory = float64(mem.Value()) / float64(memReq)
+	}
+	return u
+}
+
+func requestOrLimit(r corev1.ResourceRequirements, name corev1.ResourceName) *resource.Quantity {
+	if q, ok := r.Requests[name]; ok {
+		return &q
+	}
+	if q, ok := r.Limits[name]; ok {
+		return &q
+	}
+	return resource.NewQuantity(0, resource.DecimalSI)
+}
+
+// containerUsage sums the usage of every container in a PodMetrics object.
+func containerUsage(item unstructured.Unstructured) (corev1.ResourceList, error) {
+	containers, _, err := unstructured.NestedSlice(item.Object, "containers")
+	if err != nil {
+		return nil, err
+	}
+
+	total := corev1.ResourceList{}
+	for _, c := range containers {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		u, _, err := unstructured.NestedStringMap(cm, "usage")
+		if err != nil {
+			return nil, err
+		}
+		for name, value := range u {
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s usage %q: %w", name, value, err)
+			}
+			sum := total[corev1.ResourceName(name)]
+			sum.Add(q)
+			total[corev1.ResourceName(name)] = sum
+		}
+	}
+	return total, nil
+}
+
+// RestartCount returns the total restart count across the pod's containers.
+func RestartCount(pod corev1.Pod) int32 {
+	var n int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		n += cs.RestartCount
+	}
+	return n
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package podmetrics
+
+import (
+	"math"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func testPod() corev1.Pod {
+	return corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("500m"),
+							corev1.ResourceMemory: resource.MustParse("256Mi"),
+						},
+					},
+				},
+				{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{
+							corev1.ResourceCPU: resource.MustParse("500m"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestComputeUtilization(t *testing.T) {
+	u := ComputeUtilization(testPod(), corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("250m"),
+		corev1.ResourceMemory: resource.MustParse("128Mi"),
+	})
+
+	// CPU: 250m of (500m request + 500m limit fallback).
+	if math.Abs(u.CPU-0.25) > 1e-9 {
+		t.Errorf("expected CPU utilization 0.25, got %v", u.CPU)
+	}
+	if math.Abs(u.Memory-0.5) > 1e-9 {
+		t.Errorf("expected memory utilization 0.5, got %v", u.Memory)
+	}
+}
+
+func TestComputeUtilization_NoRequests(t *testing.T) {
+	pod := corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}}}
+	u := ComputeUtilization(pod, corev1.ResourceList{
+		corev1.ResourceCPU: resource.MustParse("250m"),
+	})
+	if u.CPU != 0 || u.Memory != 0 {
+		t.Errorf("expected zero utilization without requests, got %+v", u)
+	}
+}
+
+func TestContainerUsage(t *testing.T) {
+	item := unstructured.Unstructured{Object: map[string]interface{}{
+		"containers": []interface{}{
+			map[string]interface{}{"name": "app", "usage": map[string]interface{}{"cpu": "100m", "memory": "64Mi"}},
+			map[string]interface{}{"name": "sidecar", "usage": map[string]interface{}{"cpu": "50m", "memory": "16Mi"}},
+		},
+	}}
+
+	usage, err := containerUsage(item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cpu := usage[corev1.ResourceCPU]
+	if cpu.MilliValue() != 150 {
+		t.Errorf("expected 150m CPU, got %dm", cpu.MilliValue())
+	}
+	mem := usage[corev1.ResourceMemory]
+	if mem.Value() != 80*1024*1024 {
+		t.Errorf("expected 80Mi memory, got %d", mem.Value())
+	}
+}
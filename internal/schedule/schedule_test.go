@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *",
+		"* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "x * * * *",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) succeeded, want error", expr)
+		}
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	// 2025-06-02 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 6, day, hour, minute, 30, 0, time.UTC)
+	}
+	tests := []struct {
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"* * * * *", at(2, 3, 4), true},
+		{"0 9 * * mon-fri", at(2, 9, 0), true},
+		{"0 9 * * mon-fri", at(7, 9, 0), false},
+		{"0 9 * * MON-FRI", at(2, 9, 1), false},
+		{"*/15 * * * *", at(2, 0, 45), true},
+		{"*/15 * * * *", at(2, 0, 50), false},
+		{"10/20 * * * *", at(2, 0, 50), true},
+		{"0 0 * * 7", at(1, 0, 0), true},
+		{"@daily", at(2, 0, 0), true},
+		{"0 12 1,15 jun *", at(15, 12, 0), true},
+		// Both day fields restricted: either matches.
+		{"0 0 1 * mon", at(9, 0, 0), true},
+		{"0 0 1 * mon", at(10, 0, 0), false},
+	}
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if got := c.Matches(tt.t); got != tt.want {
+			t.Errorf("%q matches %s = %v, want %v", tt.expr, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestWindowActiveAt(t *testing.T) {
+	c, err := ParseCron("0 9 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone data")
+	}
+	w := Window{Cron: c, Duration: 8 * time.Hour, Location: berlin}
+
+	// 09:00 in Berlin is 07:00 UTC in summer.
+	open := time.Date(2025, 6, 2, 7, 0, 0, 0, time.UTC)
+	until, active := w.ActiveAt(open.Add(3 * time.Hour))
+	if !active || !until.Equal(open.Add(8*time.Hour)) {
+		t.Errorf("got until %s, active %v; want until %s", until, active, open.Add(8*time.Hour))
+	}
+	if _, active := w.ActiveAt(open.Add(8 * time.Hour)); active {
+		t.Error("window should close after its duration")
+	}
+	if _, active := w.ActiveAt(open.Add(-time.Minute)); active {
+		t.Error("window should not open early")
+	}
+}
@@ -0,0 +1,389 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+	"aviator/internal/schedule"
+)
+
+// log is for logging in this package.
+var aviatorpolicylog = logf.Log.WithName("aviatorpolicy-resource")
+
+const (
+	// defaultTopN matches the controller's fallback for topN mode.
+	defaultTopN = 3
+	// defaultPercentage matches the controller's fallback for percentage mode.
+	defaultPercentage = 50
+	// derivedServiceSuffix matches the controller's naming of derived and
+	// fronting Services.
+	derivedServiceSuffix = "-fast"
+)
+
+// SetupAviatorPolicyWebhookWithManager registers the webhook for AviatorPolicy in the manager.
+func SetupAviatorPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&aviatorv1alpha1.AviatorPolicy{}).
+		WithValidator(&AviatorPolicyCustomValidator{Client: mgr.GetClient()}).
+		WithDefaulter(&AviatorPolicyCustomDefaulter{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-aviator-example-com-v1alpha1-aviatorpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=aviator.example.com,resources=aviatorpolicies,verbs=create;update,versions=v1alpha1,name=maviatorpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AviatorPolicyCustomDefaulter fills in the defaults that depend on other
+// fields, and so cannot be expressed as CRD schema defaults.
+type AviatorPolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &AviatorPolicyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter.
+func (d *AviatorPolicyCustomDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*aviatorv1alpha1.AviatorPolicy)
+	if !ok {
+		return fmt.Errorf("expected an AviatorPolicy object but got %T", obj)
+	}
+	aviatorpolicylog.Info("Defaulting for AviatorPolicy", "name", policy.GetName())
+
+	ref := &policy.Spec.TargetRef
+	if ref.Kind == "" {
+		ref.Kind = aviatorv1alpha1.TargetKindService
+	}
+	// The schema defaults apiVersion to v1, which is only right for Services
+	// and pods.
+	if ref.APIVersion == "" || ref.APIVersion == "v1" {
+		switch ref.Kind {
+		case aviatorv1alpha1.TargetKindDeployment, aviatorv1alpha1.TargetKindStatefulSet:
+			ref.APIVersion = "apps/v1"
+		case aviatorv1alpha1.TargetKindRollout:
+			ref.APIVersion = "argoproj.io/v1alpha1"
+		default:
+			ref.APIVersion = "v1"
+		}
+	}
+
+	defaultSelection(&policy.Spec.Selection)
+	for i := range policy.Spec.Schedules {
+		if sel := policy.Spec.Schedules[i].Selection; sel != nil {
+			defaultSelection(sel)
+		}
+	}
+	return nil
+}
+
+// defaultSelection fills in the settings the selection's mode needs.
+func defaultSelection(selection *aviatorv1alpha1.SelectionPolicy) {
+	switch selection.Mode {
+	case aviatorv1alpha1.SelectionModeTopN:
+		if selection.TopN == nil {
+			n := int32(defaultTopN)
+			selection.TopN = &n
+		}
+	case aviatorv1alpha1.SelectionModePercentage:
+		if selection.Percentage == nil {
+			pct := int32(defaultPercentage)
+			selection.Percentage = &pct
+		}
+	case aviatorv1alpha1.SelectionModeOutlier:
+		if selection.Outlier == nil {
+			selection.Outlier = &aviatorv1alpha1.OutlierDetection{}
+		}
+		if selection.Outlier.Method == "" {
+			selection.Outlier.Method = aviatorv1alpha1.OutlierMethodMedian
+		}
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-aviator-example-com-v1alpha1-aviatorpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=aviator.example.com,resources=aviatorpolicies,verbs=create;update,versions=v1alpha1,name=vaviatorpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// AviatorPolicyCustomValidator rejects contradictory specs, and policies
+// that would manage the same Service as another policy in the namespace.
+type AviatorPolicyCustomValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &AviatorPolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *AviatorPolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*aviatorv1alpha1.AviatorPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an AviatorPolicy object but got %T", obj)
+	}
+	aviatorpolicylog.Info("Validation for AviatorPolicy upon creation", "name", policy.GetName())
+	return v.validate(ctx, policy)
+}
+
+// ValidateUpdate implements webhook.CustomValidator.
+func (v *AviatorPolicyCustomValidator) ValidateUpdate(ctx context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*aviatorv1alpha1.AviatorPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected an AviatorPolicy object for the newObj but got %T", newObj)
+	}
+	aviatorpolicylog.Info("Validation for AviatorPolicy upon update", "name", policy.GetName())
+	if !policy.DeletionTimestamp.IsZero() {
+		// Let the finalizer run even if the spec no longer validates.
+		return nil, nil
+	}
+	return v.validate(ctx, policy)
+}
+
+// ValidateDelete implements webhook.CustomValidator.
+func (v *AviatorPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *AviatorPolicyCustomValidator) validate(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+) (admission.Warnings, error) {
+	specPath := field.NewPath("spec")
+	allErrs := validateSpec(&policy.Spec, specPath)
+	allErrs = append(allErrs, validateSchedules(&policy.Spec, specPath)...)
+
+	warnings, errs, err := v.validateProbePort(ctx, policy, specPath)
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, errs...)
+
+	conflictErrs, err := v.validateNoConflict(ctx, policy, specPath.Child("targetRef"))
+	if err != nil {
+		return nil, err
+	}
+	allErrs = append(allErrs, conflictErrs...)
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(
+		aviatorv1alpha1.GroupVersion.WithKind("AviatorPolicy").GroupKind(), policy.Name, allErrs)
+}
+
+// validateSpec checks the combinations of fields the CRD schema cannot.
+func validateSpec(spec *aviatorv1alpha1.AviatorPolicySpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	refPath := path.Child("targetRef")
+	switch spec.TargetRef.Kind {
+	case aviatorv1alpha1.TargetKindPod:
+		if spec.TargetRef.Selector == nil {
+			allErrs = append(allErrs, field.Required(refPath.Child("selector"), "required when kind is Pod"))
+		} else if _, err := metav1.LabelSelectorAsSelector(spec.TargetRef.Selector); err != nil {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("selector"), spec.TargetRef.Selector, err.Error()))
+		}
+	default:
+		if spec.TargetRef.Selector != nil {
+			allErrs = append(allErrs, field.Forbidden(refPath.Child("selector"), "only allowed when kind is Pod"))
+		}
+	}
+	if !targetsService(spec) && spec.Routing != nil && spec.Routing.Mode == aviatorv1alpha1.RoutingModeTakeOver {
+		allErrs = append(allErrs, field.Invalid(path.Child("routing", "mode"), spec.Routing.Mode,
+			"workload targets have no Service to take over"))
+	}
+
+	selPath := path.Child("selection")
+	switch spec.Selection.Mode {
+	case aviatorv1alpha1.SelectionModeTopN:
+		if spec.Selection.TopN == nil {
+			allErrs = append(allErrs, field.Required(selPath.Child("topN"), "required when mode is topN"))
+		}
+	case aviatorv1alpha1.SelectionModeThreshold:
+		if spec.LatencyThreshold.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("latencyThreshold"), spec.LatencyThreshold.Duration.String(),
+				"must be positive when selection mode is threshold"))
+		}
+	}
+
+	// In threshold mode, a breaker threshold below latencyThreshold ejects
+	// pods that selection just accepted.
+	if cb := spec.CircuitBreaker; cb != nil && cb.Enabled &&
+		spec.Selection.Mode == aviatorv1alpha1.SelectionModeThreshold &&
+		cb.P99Threshold.Duration < spec.LatencyThreshold.Duration {
+		allErrs = append(allErrs, field.Invalid(path.Child("circuitBreaker", "p99Threshold"), cb.P99Threshold.Duration.String(),
+			fmt.Sprintf("must not be below latencyThreshold (%s)", spec.LatencyThreshold.Duration)))
+	}
+
+	if d := spec.Dampening; d != nil && d.Hysteresis != nil &&
+		d.Hysteresis.EntryThreshold.Duration >= d.Hysteresis.ExitThreshold.Duration {
+		allErrs = append(allErrs, field.Invalid(path.Child("dampening", "hysteresis", "entryThreshold"),
+			d.Hysteresis.EntryThreshold.Duration.String(),
+			fmt.Sprintf("must be below exitThreshold (%s)", d.Hysteresis.ExitThreshold.Duration)))
+	}
+	return allErrs
+}
+
+// validateSchedules checks schedule and freeze windows, and the spec each
+// schedule's overrides produce. Only problems an override introduces are
+// reported for it; the rest are reported against the spec itself.
+func validateSchedules(spec *aviatorv1alpha1.AviatorPolicySpec, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, f := range spec.Freezes {
+		allErrs = append(allErrs, validateWindow(f, path.Child("freezes").Index(i))...)
+	}
+	for i, o := range spec.Schedules {
+		schedPath := path.Child("schedules").Index(i)
+		allErrs = append(allErrs, validateWindow(o.ScheduleWindow, schedPath)...)
+
+		merged := spec.DeepCopy()
+		if o.LatencyThreshold != nil {
+			merged.LatencyThreshold = *o.LatencyThreshold
+		}
+		if o.Selection != nil {
+			merged.Selection = *o.Selection
+		}
+		if o.CircuitBreaker != nil {
+			merged.CircuitBreaker = o.CircuitBreaker
+		}
+		if o.Dampening != nil {
+			merged.Dampening = o.Dampening
+		}
+		existing := make(map[string]bool)
+		for _, err := range validateSpec(spec, schedPath) {
+			existing[err.Error()] = true
+		}
+		for _, err := range validateSpec(merged, schedPath) {
+			if !existing[err.Error()] {
+				allErrs = append(allErrs, err)
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateWindow checks that a window's cron expression and time zone parse
+// and that it stays open for some time.
+func validateWindow(w aviatorv1alpha1.ScheduleWindow, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if _, err := schedule.ParseCron(w.Cron); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("cron"), w.Cron, err.Error()))
+	}
+	if w.Duration.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("duration"), w.Duration.Duration.String(), "must be positive"))
+	}
+	if w.TimeZone != "" {
+		if _, err := time.LoadLocation(w.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("timeZone"), w.TimeZone, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateProbePort rejects a probe-sourced policy without a targetPort
+// whose Service has no TCP port to fall back on. A missing Service is only
+// warned about, since it may be created after the policy.
+func (v *AviatorPolicyCustomValidator) validateProbePort(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	path *field.Path,
+) (admission.Warnings, field.ErrorList, error) {
+	if policy.Spec.LatencySource != aviatorv1alpha1.LatencySourceProbe ||
+		policy.Spec.TargetPort != nil || !targetsService(&policy.Spec) {
+		return nil, nil, nil
+	}
+
+	var service corev1.Service
+	key := types.NamespacedName{Name: policy.Spec.TargetRef.Name, Namespace: policy.Namespace}
+	if err := v.Client.Get(ctx, key, &service); err != nil {
+		if apierrors.IsNotFound(err) {
+			return admission.Warnings{fmt.Sprintf(
+				"Service %s not found; the probe port cannot be checked until it exists", key.Name)}, nil, nil
+		}
+		return nil, nil, err
+	}
+	for _, port := range service.Spec.Ports {
+		if port.Protocol == "" || port.Protocol == corev1.ProtocolTCP {
+			return nil, nil, nil
+		}
+	}
+	return nil, field.ErrorList{field.Required(path.Child("targetPort"),
+		fmt.Sprintf("Service %s has no TCP port to probe", key.Name))}, nil
+}
+
+// validateNoConflict rejects a policy that would manage a Service another
+// enforcing policy in the namespace already manages. Observing policies
+// never change routing, so they cannot conflict.
+func (v *AviatorPolicyCustomValidator) validateNoConflict(
+	ctx context.Context,
+	policy *aviatorv1alpha1.AviatorPolicy,
+	path *field.Path,
+) (field.ErrorList, error) {
+	if policy.Spec.Mode == aviatorv1alpha1.PolicyModeObserve {
+		return nil, nil
+	}
+
+	var list aviatorv1alpha1.AviatorPolicyList
+	if err := v.Client.List(ctx, &list, client.InNamespace(policy.Namespace)); err != nil {
+		return nil, err
+	}
+
+	mine := managedServices(policy)
+	var allErrs field.ErrorList
+	for i := range list.Items {
+		other := &list.Items[i]
+		if other.Name == policy.Name || other.Spec.Mode == aviatorv1alpha1.PolicyModeObserve ||
+			!other.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for name := range managedServices(other) {
+			if mine[name] {
+				allErrs = append(allErrs, field.Forbidden(path,
+					fmt.Sprintf("Service %s is already managed by AviatorPolicy %s", name, other.Name)))
+				break
+			}
+		}
+	}
+	return allErrs, nil
+}
+
+// managedServices returns the names of the Services a policy writes
+// EndpointSlices for or reconfigures.
+func managedServices(policy *aviatorv1alpha1.AviatorPolicy) map[string]bool {
+	spec := &policy.Spec
+	services := make(map[string]bool)
+	if targetsService(spec) {
+		services[spec.TargetRef.Name] = true
+	}
+	if !targetsService(spec) || (spec.Routing != nil && spec.Routing.Mode == aviatorv1alpha1.RoutingModeDerivedService) {
+		name := spec.TargetRef.Name + derivedServiceSuffix
+		if spec.Routing != nil && spec.Routing.ServiceName != "" {
+			name = spec.Routing.ServiceName
+		}
+		services[name] = true
+	}
+	return services
+}
+
+// targetsService reports whether the spec targets an existing Service.
+func targetsService(spec *aviatorv1alpha1.AviatorPolicySpec) bool {
+	return spec.TargetRef.Kind == "" || spec.TargetRef.Kind == aviatorv1alpha1.TargetKindService
+}
@@ -0,0 +1,240 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	aviatorv1alpha1 "aviator/api/v1alpha1"
+)
+
+func newValidator(objs ...client.Object) *AviatorPolicyCustomValidator {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = aviatorv1alpha1.AddToScheme(scheme)
+	return &AviatorPolicyCustomValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+	}
+}
+
+func testPolicy(name, service string) *aviatorv1alpha1.AviatorPolicy {
+	return &aviatorv1alpha1.AviatorPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: aviatorv1alpha1.AviatorPolicySpec{
+			TargetRef:        aviatorv1alpha1.TargetRef{Kind: aviatorv1alpha1.TargetKindService, Name: service},
+			LatencyThreshold: metav1.Duration{Duration: 100 * time.Millisecond},
+			Selection:        aviatorv1alpha1.SelectionPolicy{Mode: aviatorv1alpha1.SelectionModePercentage},
+			LatencySource:    aviatorv1alpha1.LatencySourceEBPF,
+		},
+	}
+}
+
+func TestDefault(t *testing.T) {
+	policy := testPolicy("p", "web")
+	policy.Spec.TargetRef = aviatorv1alpha1.TargetRef{APIVersion: "v1", Kind: aviatorv1alpha1.TargetKindDeployment, Name: "web"}
+	policy.Spec.Selection.Mode = aviatorv1alpha1.SelectionModeTopN
+
+	if err := (&AviatorPolicyCustomDefaulter{}).Default(context.Background(), policy); err != nil {
+		t.Fatal(err)
+	}
+	if got := policy.Spec.TargetRef.APIVersion; got != "apps/v1" {
+		t.Errorf("apiVersion = %q, want apps/v1", got)
+	}
+	if policy.Spec.Selection.TopN == nil || *policy.Spec.Selection.TopN != defaultTopN {
+		t.Errorf("topN = %v, want %d", policy.Spec.Selection.TopN, defaultTopN)
+	}
+}
+
+func TestValidateSpec(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*aviatorv1alpha1.AviatorPolicy)
+		wantErr bool
+	}{
+		{"valid", func(*aviatorv1alpha1.AviatorPolicy) {}, false},
+		{"topN without topN", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.Selection.Mode = aviatorv1alpha1.SelectionModeTopN
+		}, true},
+		{"breaker below threshold", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.Selection.Mode = aviatorv1alpha1.SelectionModeThreshold
+			p.Spec.CircuitBreaker = &aviatorv1alpha1.CircuitBreakerSpec{
+				Enabled:      true,
+				P99Threshold: metav1.Duration{Duration: 50 * time.Millisecond},
+			}
+		}, true},
+		{"breaker below threshold outside threshold mode", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.CircuitBreaker = &aviatorv1alpha1.CircuitBreakerSpec{
+				Enabled:      true,
+				P99Threshold: metav1.Duration{Duration: 50 * time.Millisecond},
+			}
+		}, false},
+		{"hysteresis inverted", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.Dampening = &aviatorv1alpha1.DampeningSpec{Hysteresis: &aviatorv1alpha1.HysteresisSpec{
+				ExitThreshold:  metav1.Duration{Duration: 100 * time.Millisecond},
+				EntryThreshold: metav1.Duration{Duration: 200 * time.Millisecond},
+			}}
+		}, true},
+		{"pod target without selector", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.TargetRef.Kind = aviatorv1alpha1.TargetKindPod
+		}, true},
+		{"selector on Service target", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.TargetRef.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+		}, true},
+		{"takeOver of a workload", func(p *aviatorv1alpha1.AviatorPolicy) {
+			p.Spec.TargetRef.Kind = aviatorv1alpha1.TargetKindDeployment
+			p.Spec.Routing = &aviatorv1alpha1.RoutingSpec{Mode: aviatorv1alpha1.RoutingModeTakeOver}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := testPolicy("p", "web")
+			tt.mutate(policy)
+			errs := validateSpec(&policy.Spec, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("got errors %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateProbePort(t *testing.T) {
+	udpOnly := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "dns", Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: 53, Protocol: corev1.ProtocolUDP}}},
+	}
+	v := newValidator(udpOnly)
+
+	policy := testPolicy("p", "dns")
+	policy.Spec.LatencySource = aviatorv1alpha1.LatencySourceProbe
+	if _, err := v.ValidateCreate(context.Background(), policy); !apierrors.IsInvalid(err) {
+		t.Errorf("probe without a TCP port: got %v, want Invalid", err)
+	}
+
+	port := int32(8080)
+	policy.Spec.TargetPort = &port
+	if _, err := v.ValidateCreate(context.Background(), policy); err != nil {
+		t.Errorf("probe with targetPort: %v", err)
+	}
+
+	missing := testPolicy("p", "missing")
+	missing.Spec.LatencySource = aviatorv1alpha1.LatencySourceProbe
+	warnings, err := v.ValidateCreate(context.Background(), missing)
+	if err != nil || len(warnings) != 1 {
+		t.Errorf("missing Service: got warnings %v, err %v; want one warning", warnings, err)
+	}
+}
+
+func TestValidateNoConflict(t *testing.T) {
+	existing := testPolicy("first", "web")
+	v := newValidator(existing)
+
+	if _, err := v.ValidateCreate(context.Background(), testPolicy("second", "web")); !apierrors.IsInvalid(err) {
+		t.Errorf("same target Service: got %v, want Invalid", err)
+	}
+	if _, err := v.ValidateCreate(context.Background(), testPolicy("second", "api")); err != nil {
+		t.Errorf("different Service: %v", err)
+	}
+	if _, err := v.ValidateUpdate(context.Background(), existing, existing); err != nil {
+		t.Errorf("updating the existing policy: %v", err)
+	}
+
+	observing := testPolicy("second", "web")
+	observing.Spec.Mode = aviatorv1alpha1.PolicyModeObserve
+	if _, err := v.ValidateCreate(context.Background(), observing); err != nil {
+		t.Errorf("observing policy: %v", err)
+	}
+
+	derived := testPolicy("second", "api")
+	derived.Spec.Routing = &aviatorv1alpha1.RoutingSpec{
+		Mode:        aviatorv1alpha1.RoutingModeDerivedService,
+		ServiceName: "web",
+	}
+	if _, err := v.ValidateCreate(context.Background(), derived); !apierrors.IsInvalid(err) {
+		t.Errorf("derived Service named like another target: got %v, want Invalid", err)
+	}
+}
+
+func TestValidateSchedules(t *testing.T) {
+	window := aviatorv1alpha1.ScheduleWindow{Name: "peak", Cron: "0 9 * * mon-fri", Duration: metav1.Duration{Duration: 8 * time.Hour}}
+	tests := []struct {
+		name    string
+		mutate  func(*aviatorv1alpha1.AviatorPolicySpec)
+		wantErr bool
+	}{
+		{"valid", func(s *aviatorv1alpha1.AviatorPolicySpec) {
+			s.Schedules = []aviatorv1alpha1.ScheduledOverride{{ScheduleWindow: window}}
+			s.Freezes = []aviatorv1alpha1.ScheduleWindow{window}
+		}, false},
+		{"bad cron", func(s *aviatorv1alpha1.AviatorPolicySpec) {
+			w := window
+			w.Cron = "0 25 * * *"
+			s.Freezes = []aviatorv1alpha1.ScheduleWindow{w}
+		}, true},
+		{"bad time zone", func(s *aviatorv1alpha1.AviatorPolicySpec) {
+			w := window
+			w.TimeZone = "Mars/Olympus"
+			s.Freezes = []aviatorv1alpha1.ScheduleWindow{w}
+		}, true},
+		{"zero duration", func(s *aviatorv1alpha1.AviatorPolicySpec) {
+			w := window
+			w.Duration = metav1.Duration{}
+			s.Schedules = []aviatorv1alpha1.ScheduledOverride{{ScheduleWindow: w}}
+		}, true},
+		{"override topN without topN", func(s *aviatorv1alpha1.AviatorPolicySpec) {
+			s.Schedules = []aviatorv1alpha1.ScheduledOverride{{
+				ScheduleWindow: window,
+				Selection:      &aviatorv1alpha1.SelectionPolicy{Mode: aviatorv1alpha1.SelectionModeTopN},
+			}}
+		}, true},
+		{"override threshold above base breaker", func(s *aviatorv1alpha1.AviatorPolicySpec) {
+			s.Selection.Mode = aviatorv1alpha1.SelectionModeThreshold
+			s.CircuitBreaker = &aviatorv1alpha1.CircuitBreakerSpec{Enabled: true, P99Threshold: metav1.Duration{Duration: 200 * time.Millisecond}}
+			s.Schedules = []aviatorv1alpha1.ScheduledOverride{{
+				ScheduleWindow:   window,
+				LatencyThreshold: &metav1.Duration{Duration: 300 * time.Millisecond},
+			}}
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := testPolicy("p", "web")
+			tt.mutate(&policy.Spec)
+			errs := validateSchedules(&policy.Spec, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("got errors %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSchedulesSkipsBaseErrors(t *testing.T) {
+	policy := testPolicy("p", "web")
+	policy.Spec.Selection.Mode = aviatorv1alpha1.SelectionModeTopN
+	policy.Spec.Schedules = []aviatorv1alpha1.ScheduledOverride{{ScheduleWindow: aviatorv1alpha1.ScheduleWindow{
+		Name: "peak", Cron: "@daily", Duration: metav1.Duration{Duration: time.Hour},
+	}}}
+	// The missing topN is the spec's problem, not the schedule's.
+	if errs := validateSchedules(&policy.Spec, nil); len(errs) > 0 {
+		t.Errorf("got %v, want no schedule errors", errs)
+	}
+}
@@ -0,0 +1,31 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	aviatorv1alpha2 "aviator/api/v1alpha2"
+)
+
+// SetupAviatorPolicyWebhookWithManager registers the conversion webhook for
+// AviatorPolicy in the manager. Defaulting and validation run on v1alpha1,
+// to which the API server converts v1alpha2 requests.
+func SetupAviatorPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&aviatorv1alpha2.AviatorPolicy{}).
+		Complete()
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package xds
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// MaxWeight is the load balancing weight of the fastest endpoint.
+const MaxWeight = 100
+
+// Endpoint is one pod in a ClusterLoadAssignment.
+type Endpoint struct {
+	Address string
+	Port    uint32
+	Zone    string
+	// Weight is the endpoint's load_balancing_weight (at least 1).
+	Weight uint32
+	Health corev3.HealthStatus
+}
+
+// ClusterName returns the EDS cluster name for a Service port. Envoy clusters
+// reference it as their eds_cluster_config.service_name.
+func ClusterName(namespace, service string, port int32) string {
+	return fmt.Sprintf("%s/%s:%d", namespace, service, port)
+}
+
+// LatencyWeight returns a load balancing weight inversely proportional to
+// p99: MaxWeight for the fastest endpoint, down to a minimum of 1.
+func LatencyWeight(p99, fastest time.Duration) uint32 {
+	if p99 <= 0 || fastest <= 0 || p99 <= fastest {
+		return MaxWeight
+	}
+	w := uint32(int64(MaxWeight) * int64(fastest) / int64(p99))
+	if w < 1 {
+		return 1
+	}
+	return w
+}
+
+// LoadAssignment builds a ClusterLoadAssignment, with one locality per zone
+// in zone order.
+func LoadAssignment(cluster string, endpoints []Endpoint) *endpointv3.ClusterLoadAssignment {
+	byZone := make(map[string][]*endpointv3.LbEndpoint)
+	for _, ep := range endpoints {
+		weight := ep.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		byZone[ep.Zone] = append(byZone[ep.Zone], &endpointv3.LbEndpoint{
+			HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
+				Endpoint: &endpointv3.Endpoint{
+					Address: &corev3.Address{
+						Address: &corev3.Address_SocketAddress{
+							SocketAddress: &corev3.SocketAddress{
+								Protocol: corev3.SocketAddress_TCP,
+								Address:  ep.Address,
+								PortSpecifier: &corev3.SocketAddress_PortValue{
+									PortValue: ep.Port,
+								},
+							},
+						},
+					},
+				},
+			},
+			HealthStatus:        ep.Health,
+			LoadBalancingWeight: wrapperspb.UInt32(weight),
+		})
+	}
+
+	zones := make([]string, 0, len(byZone))
+	for zone := range byZone {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	cla := &endpointv3.ClusterLoadAssignment{ClusterName: cluster}
+	for _, zone := range zones {
+		cla.Endpoints = append(cla.Endpoints, &endpointv3.LocalityLbEndpoints{
+			Locality:    &corev3.Locality{Zone: zone},
+			LbEndpoints: byZone[zone],
+		})
+	}
+	return cla
+}
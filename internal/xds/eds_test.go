@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package xds
+
+import (
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+)
+
+func TestLatencyWeight(t *testing.T) {
+	tests := []struct {
+		p99, fastest time.Duration
+		want         uint32
+	}{
+		{10 * time.Millisecond, 10 * time.Millisecond, MaxWeight},
+		{20 * time.Millisecond, 10 * time.Millisecond, MaxWeight / 2},
+		{10 * time.Second, 10 * time.Millisecond, 1},
+		{0, 10 * time.Millisecond, MaxWeight},
+	}
+	for _, tt := range tests {
+		if got := LatencyWeight(tt.p99, tt.fastest); got != tt.want {
+			t.Errorf("LatencyWeight(%v, %v) = %d, want %d", tt.p99, tt.fastest, got, tt.want)
+		}
+	}
+}
+
+func TestLoadAssignment_GroupsByZone(t *testing.T) {
+	cla := LoadAssignment("default/web:80", []Endpoint{
+		{Address: "10.0.0.1", Port: 8080, Zone: "b", Weight: 100, Health: corev3.HealthStatus_HEALTHY},
+		{Address: "10.0.0.2", Port: 8080, Zone: "a", Weight: 50, Health: corev3.HealthStatus_HEALTHY},
+		{Address: "10.0.0.3", Port: 8080, Zone: "b", Health: corev3.HealthStatus_UNHEALTHY},
+	})
+
+	if len(cla.Endpoints) != 2 {
+		t.Fatalf("expected 2 localities, got %d", len(cla.Endpoints))
+	}
+	if cla.Endpoints[0].Locality.Zone != "a" || len(cla.Endpoints[1].LbEndpoints) != 2 {
+		t.Errorf("unexpected localities: %v", cla.Endpoints)
+	}
+	unhealthy := cla.Endpoints[1].LbEndpoints[1]
+	if unhealthy.LoadBalancingWeight.GetValue() != 1 {
+		t.Errorf("expected zero weight to be raised to 1, got %d", unhealthy.LoadBalancingWeight.GetValue())
+	}
+	if unhealthy.HealthStatus != corev3.HealthStatus_UNHEALTHY {
+		t.Errorf("expected UNHEALTHY, got %v", unhealthy.HealthStatus)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+// Package xds serves Aviator's routing decisions to Envoy over the xDS
+// Endpoint Discovery Service.
+package xds
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"sync"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	discoverygrpc "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	xdslog "github.com/envoyproxy/go-control-plane/pkg/log"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+)
+
+// snapshotNode is the node key shared by every Envoy: all clients see the
+// same assignments.
+const snapshotNode = "aviator"
+
+type allNodes struct{}
+
+func (allNodes) ID(*corev3.Node) string { return snapshotNode }
+
+// Server is an xDS control plane serving EDS ClusterLoadAssignments. It
+// implements manager.Runnable.
+type Server struct {
+	addr  string
+	log   logr.Logger
+	cache cachev3.SnapshotCache
+
+	mu          sync.Mutex
+	version     uint64
+	assignments map[string][]*endpointv3.ClusterLoadAssignment
+}
+
+// NewServer creates an xDS server that listens on addr once started.
+func NewServer(addr string, log logr.Logger) *Server {
+	log = log.WithName("xds")
+	return &Server{
+		addr:        addr,
+		log:         log,
+		cache:       cachev3.NewSnapshotCache(false, allNodes{}, logAdapter(log)),
+		assignments: make(map[string][]*endpointv3.ClusterLoadAssignment),
+	}
+}
+
+// Update replaces the assignments published for key, typically a policy.
+func (s *Server) Update(ctx context.Context, key string, assignments []*endpointv3.ClusterLoadAssignment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assignments[key] = assignments
+	return s.publish(ctx)
+}
+
+// Remove stops publishing the assignments for key.
+func (s *Server) Remove(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.assignments[key]; !ok {
+		return nil
+	}
+	delete(s.assignments, key)
+	return s.publish(ctx)
+}
+
+// Assignments returns the assignments published for key.
+func (s *Server) Assignments(key string) []*endpointv3.ClusterLoadAssignment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.assignments[key]
+}
+
+// publish sets a new snapshot with every assignment. s.mu must be held.
+func (s *Server) publish(ctx context.Context) error {
+	keys := make([]string, 0, len(s.assignments))
+	for key := range s.assignments {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var resources []types.Resource
+	for _, key := range keys {
+		for _, cla := range s.assignments[key] {
+			resources = append(resources, cla)
+		}
+	}
+
+	s.version++
+	snapshot, err := cachev3.NewSnapshot(strconv.FormatUint(s.version, 10), map[resource.Type][]types.Resource{
+		resource.EndpointType: resources,
+	})
+	if err != nil {
+		return fmt.Errorf("building xDS snapshot: %w", err)
+	}
+	return s.cache.SetSnapshot(ctx, snapshotNode, snapshot)
+}
+
+// Start listens on the server's address and serves xDS until ctx is done.
+func (s *Server) Start(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listening for xDS: %w", err)
+	}
+	return s.Serve(ctx, lis)
+}
+
+// Serve serves EDS and ADS on lis until ctx is done.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	xds := serverv3.NewServer(ctx, s.cache, nil)
+	grpcServer := grpc.NewServer()
+	endpointservice.RegisterEndpointDiscoveryServiceServer(grpcServer, xds)
+	discoverygrpc.RegisterAggregatedDiscoveryServiceServer(grpcServer, xds)
+
+	go func() {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+	}()
+
+	s.log.Info("serving xDS", "address", lis.Addr().String())
+	return grpcServer.Serve(lis)
+}
+
+// NeedLeaderElection makes only the leader serve xDS, as only the leader
+// reconciles policies.
+func (s *Server) NeedLeaderElection() bool { return true }
+
+func logAdapter(log logr.Logger) xdslog.Logger {
+	return xdslog.LoggerFuncs{
+		DebugFunc: func(format string, args ...interface{}) { log.V(1).Info(fmt.Sprintf(format, args...)) },
+		InfoFunc:  func(format string, args ...interface{}) { log.V(1).Info(fmt.Sprintf(format, args...)) },
+		WarnFunc:  func(format string, args ...interface{}) { log.Info(fmt.Sprintf(format, args...)) },
+		ErrorFunc: func(format string, args ...interface{}) { log.Error(nil, fmt.Sprintf(format, args...)) },
+	}
+}
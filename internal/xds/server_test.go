@@ -0,0 +1,91 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+*/
+
+package xds
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	endpointservice "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/go-logr/logr"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func TestServer_ServesEDS(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := NewServer("", logr.Discard())
+	go func() { _ = s.Serve(ctx, lis) }()
+
+	cluster := ClusterName("default", "web", 80)
+	err = s.Update(ctx, "default/policy", []*endpointv3.ClusterLoadAssignment{
+		LoadAssignment(cluster, []Endpoint{
+			{Address: "10.0.0.1", Port: 8080, Weight: 100, Health: corev3.HealthStatus_HEALTHY},
+			{Address: "10.0.0.2", Port: 8080, Weight: 1, Health: corev3.HealthStatus_UNHEALTHY},
+		}),
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	stream, err := endpointservice.NewEndpointDiscoveryServiceClient(conn).StreamEndpoints(ctx)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	err = stream.Send(&discoveryv3.DiscoveryRequest{
+		Node:          &corev3.Node{Id: "envoy-1"},
+		TypeUrl:       resource.EndpointType,
+		ResourceNames: []string{cluster},
+	})
+	if err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+
+	if len(resp.Resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resp.Resources))
+	}
+	var cla endpointv3.ClusterLoadAssignment
+	if err := resp.Resources[0].UnmarshalTo(&cla); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if cla.ClusterName != cluster {
+		t.Errorf("expected cluster %s, got %s", cluster, cla.ClusterName)
+	}
+	lbEndpoints := cla.Endpoints[0].LbEndpoints
+	if len(lbEndpoints) != 2 {
+		t.Fatalf("expected 2 endpoints, got %d", len(lbEndpoints))
+	}
+	if lbEndpoints[0].LoadBalancingWeight.GetValue() != 100 || lbEndpoints[1].HealthStatus != corev3.HealthStatus_UNHEALTHY {
+		t.Errorf("unexpected endpoints: %v", lbEndpoints)
+	}
+}